@@ -9,7 +9,7 @@ type ScheduleModel struct {
 	src.PermutationModel
 }
 
-func (sm ScheduleModel) Crossover(parent1 src.Individual, parent2 src.Individual) (src.Individual, error) {
+func (sm ScheduleModel) Crossover(parent1 src.Individual, parent2 src.Individual, rng *rand.Rand) (src.Individual, error) {
 	// Map of tasks for each parent: task -> day number. Sorted by task.
 	// When selecting tasks for child, look at each task and randomly choose one and insert it into child' relevant day.
 	parent1Schedule := parent1.(Schedule)
@@ -19,7 +19,7 @@ func (sm ScheduleModel) Crossover(parent1 src.Individual, parent2 src.Individual
 
 	child := generateSchedule()
 	for _, task := range parent1Schedule.Tasks {
-		if rand.Float64() <= 0.5 {
+		if rng.Float64() <= 0.5 {
 			child.Genes[tasksByDayParent1[task.Title]].Tasks = append(child.Genes[tasksByDayParent1[task.Title]].Tasks, task)
 		} else {
 			child.Genes[tasksByDayParent2[task.Title]].Tasks = append(child.Genes[tasksByDayParent2[task.Title]].Tasks, task)
@@ -91,15 +91,15 @@ func (s Schedule) CalculateFitness() float64 {
 	return fitnessScore
 }
 
-func (s Schedule) GenerateIndividual() src.Individual {
+func (s Schedule) GenerateIndividual(rng *rand.Rand) src.Individual {
 	var localTasks []Task
 	localTasks = append(localTasks, s.Tasks...)
 	individual := generateSchedule()
 	for len(localTasks) > 0 {
 		for i := 0; i < s.DayAmount && len(localTasks) > 0; i++ {
-			if rand.Float64() < s.IndividualInsertChance {
+			if rng.Float64() < s.IndividualInsertChance {
 				// insert randomly selected task to the day
-				randomTaskIndex := rand.Intn(len(localTasks))
+				randomTaskIndex := rng.Intn(len(localTasks))
 				individual.Genes[i].Tasks = append(individual.Genes[i].Tasks, localTasks[randomTaskIndex])
 
 				// remove randomly selected task from whole tasks
@@ -110,10 +110,47 @@ func (s Schedule) GenerateIndividual() src.Individual {
 	return individual
 }
 
+// Len, Get, Set, Swap and Clone make Schedule a src.Genome of days, so the built-in
+// operators can move days around.
+func (s Schedule) Len() int {
+	return len(s.Genes)
+}
+
+func (s Schedule) Get(i int) interface{} {
+	return s.Genes[i]
+}
+
+func (s Schedule) Set(i int, gene interface{}) {
+	s.Genes[i] = gene.(Day)
+}
+
+func (s Schedule) Swap(i int, j int) {
+	s.Genes[i], s.Genes[j] = s.Genes[j], s.Genes[i]
+}
+
+// Clone copies the days and their task lists. The list of all tasks is shared.
+func (s Schedule) Clone() src.Individual {
+	clone := s
+	clone.Genes = make([]Day, len(s.Genes))
+	for i, day := range s.Genes {
+		clone.Genes[i] = Day{Tasks: append([]Task(nil), day.Tasks...)}
+	}
+	return clone
+}
+
 func main() {
 	defer timer("main")()
 
-	ga := src.NewCustomGA(20, 1000, 0.3, 0.01, generateSchedule(), ScheduleModel{})
+	ga, err := src.NewGA(generateSchedule(),
+		src.WithGenerations(20),
+		src.WithPopulationSize(1000),
+		src.WithMutationRate(0.3),
+		src.WithElitismRate(0.01),
+		src.WithModel(ScheduleModel{}),
+	)
+	if err != nil {
+		panic(err)
+	}
 	var bestSchedule Schedule
 	bestSchedule = ga.RunWithLog(printSchedule).(Schedule)
 
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
 	"math/rand"
@@ -31,35 +30,32 @@ func (c Characters) CalculateFitness() float64 {
 	return result
 }
 
-func (c Characters) GenerateIndividual() src.Individual {
+func (c Characters) GenerateIndividual(rng *rand.Rand) src.Individual {
 	individual := make(Characters, len(target))
 	for i := 0; i < len(target); i++ {
-		individual[i] = alleles[rand.Intn(len(alleles))]
+		individual[i] = alleles[rng.Intn(len(alleles))]
 	}
 	return individual
 }
 
-type StringModel struct {
-	src.DefaultModel
-}
-
-func (sm StringModel) Mutate(individual src.Individual) (src.Individual, error) {
-	// Assert the individual to type Characters
-	charactersIndividual, ok := individual.(Characters)
-	if !ok {
-		// Handle the case where the assertion fails, e.g., return an error
-		return nil, errors.New("individual is not of type Characters")
-	}
-	// Perform the mutation on charactersIndividual
-	charactersIndividual[rand.Intn(len(charactersIndividual))] = alleles[rand.Intn(len(alleles))]
-	return charactersIndividual, nil
+// Alleles makes Characters an AlleleProvider, so DefaultModel can mutate it
+func (c Characters) Alleles(locus int) interface{} {
+	return alleles
 }
 
 func main() {
 	defer Timer("main")()
 
-	// Models can be given using Enum. (Models.StringModel).
-	ga := src.NewCustomGA(20, 2000, 0.5, 0.01, Characters{}, StringModel{})
+	ga, err := src.NewGA(Characters{},
+		src.WithGenerations(20),
+		src.WithPopulationSize(2000),
+		src.WithMutationRate(0.5),
+		src.WithElitismRate(0.01),
+		src.WithModel(src.DefaultModel{}),
+	)
+	if err != nil {
+		panic(err)
+	}
 	var bestCharacters Characters
 	bestCharacters = ga.Run().(Characters)
 	for _, gen := range bestCharacters {
@@ -77,7 +73,7 @@ func main() {
 	cmd := exec.Command("ps", "-p", fmt.Sprintf("%d", os.Getpid()), "-o", "%cpu")
 	cmd.Stderr = os.Stderr
 	cmd.Stdout = os.Stdout
-	err := cmd.Run()
+	err = cmd.Run()
 	if err != nil {
 		fmt.Println("Error getting CPU usage:", err)
 	}
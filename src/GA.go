@@ -1,70 +1,309 @@
 package src
 
+import (
+	"context"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"time"
+)
+
 type GA struct {
-	generationNumber int
-	populationSize   int
-	population       Population
+	generationNumber  int
+	populationSize    int
+	population        Population
+	termination       TerminationCriterion
+	terminationReason string
+	generationHooks   []printIndividual
+	observers         []Observer
+	diversityStats    bool
+	stopped           int32
+	history           []GenerationStats
+	checkpoint        *checkpointConfig
+	best              Individual
+	bestFitness       float64
+	bestGeneration    int
+	runEnd            int // generation the current run stops at, 0 between runs
 }
 
 type printIndividual func(individual Individual)
 
+// Run breeds up to the configured number of generations and returns the best individual
+// found. Calling it again continues with the same population for as many generations more.
+//
 // Implement user provided callback function that takes individual as parameter
 // We will call this callback with parameter g.population.calculateBestIndividual
 // User can get properties of this individual and save it in an array and use it.
 func (g *GA) Run() Individual {
-	for i := 0; i < g.generationNumber; i++ {
-		g.population.evolveParallel()
+	result, _ := g.run(context.Background(), nil)
+	return result.Best
+}
+
+func (g *GA) RunWithLog(printIndividual printIndividual) Individual {
+	result, _ := g.run(context.Background(), printIndividual)
+	return result.Best
+}
+
+// RunContext is like Run but checks ctx between generations and while offspring are bred
+// and evaluated. When ctx is cancelled or its deadline passes, the generation in progress
+// is dropped and the best individual found so far is returned together with ctx.Err(), so
+// callers still get a usable answer. An operator error that aborts the run is returned
+// the same way.
+func (g *GA) RunContext(ctx context.Context) (Individual, error) {
+	result, err := g.run(ctx, nil)
+	return result.Best, err
+}
+
+// RunWithResult runs like RunContext and describes the whole run in a RunResult. The
+// result is filled in even when an error is returned.
+func (g *GA) RunWithResult(ctx context.Context) (RunResult, error) {
+	return g.run(ctx, nil)
+}
+
+// SetTermination sets the criterion that ends a run early. The generation number given
+// to the constructor always stays in effect as an upper bound on the generations a run
+// breeds.
+func (g *GA) SetTermination(criterion TerminationCriterion) {
+	g.termination = criterion
+}
+
+// TerminationReason tells why the last run stopped.
+func (g *GA) TerminationReason() string {
+	return g.terminationReason
+}
+
+// Generation is the number of generations the population has been bred for. Operators
+// may read it while offspring are bred.
+func (g *GA) Generation() int {
+	return g.population.generation
+}
+
+// Seed is the seed of the run. Giving it to WithSeed repeats the run.
+func (g *GA) Seed() int64 {
+	return g.population.seed
+}
+
+// OperatorErrors tells how many times each operator failed so far, in all runs.
+func (g *GA) OperatorErrors() OperatorErrorCounts {
+	return OperatorErrorCounts{
+		Crossover: int(atomic.LoadInt64(&g.population.crossoverErrors)),
+		Mutation:  int(atomic.LoadInt64(&g.population.mutationErrors)),
 	}
+}
 
-	return g.population.calculateBestIndividual()
+// History holds the statistics of the initial population and of every bred generation.
+func (g *GA) History() []GenerationStats {
+	return g.history
 }
 
-func (g *GA) RunWithLog(printIndividual printIndividual) Individual {
-	for i := 0; i < g.generationNumber; i++ {
-		g.population.evolveParallel()
-		bestOne := g.population.calculateBestIndividual()
-		printIndividual(bestOne)
+// Stop ends the current run after the generation that is being bred. It is safe to call
+// from observers and from other goroutines.
+func (g *GA) Stop() {
+	atomic.StoreInt32(&g.stopped, 1)
+}
+
+func (g *GA) run(ctx context.Context, printIndividual printIndividual) (RunResult, error) {
+	// A resumed run keeps the bound of the run that was saved.
+	if g.runEnd <= g.population.generation {
+		g.runEnd = g.population.generation + g.generationNumber
+	}
+	criterion := MaxGenerations(g.runEnd)
+	if g.termination != nil {
+		criterion = Or(criterion, g.termination)
+	}
+	withDiversity := g.diversityStats || usesDiversity(criterion) || len(g.observers) > 0
+
+	start := time.Now()
+	phasesBefore := g.population.phases
+	evaluationsBefore := g.population.evaluations
+	errorsBefore := g.OperatorErrors()
+	atomic.StoreInt32(&g.stopped, 0)
+	if err := g.population.ensureEvaluatedContext(ctx); err != nil {
+		g.terminationReason = err.Error()
+		g.runEnd = 0
+		return RunResult{TerminationReason: err.Error()}, err
+	}
+	if g.best == nil {
+		g.best = g.population.calculateBestIndividual()
+		g.bestFitness = g.population.calculateBestFitness()
+		g.bestGeneration = g.population.generation
+	}
+	if len(g.history) == 0 {
+		g.history = append(g.history, g.population.stats(withDiversity))
+	}
+	historyStart := len(g.history) - 1
+	result := RunResult{
+		Best:           g.best,
+		BestFitness:    g.bestFitness,
+		BestGeneration: g.bestGeneration,
+	}
+	state := RunState{
+		Objective:       g.population.objective,
+		Generation:      g.population.generation,
+		BestFitness:     g.bestFitness,
+		LastImprovement: g.bestGeneration,
+	}
+	var interrupts chan os.Signal
+	if g.checkpoint != nil {
+		interrupts = make(chan os.Signal, 1)
+		signal.Notify(interrupts, os.Interrupt)
+		defer signal.Stop(interrupts)
+	}
+	finish := func(reason string) {
+		g.terminationReason = reason
+		g.runEnd = 0
+		result.Generations = g.population.generation
+		result.Evaluations = g.population.evaluations - evaluationsBefore
+		result.Duration = time.Since(start)
+		result.Phases = PhaseDurations{
+			Breeding:   g.population.phases.Breeding - phasesBefore.Breeding,
+			Evaluation: g.population.phases.Evaluation - phasesBefore.Evaluation,
+		}
+		result.TerminationReason = reason
+		operatorErrors := g.OperatorErrors()
+		result.OperatorErrors = OperatorErrorCounts{
+			Crossover: operatorErrors.Crossover - errorsBefore.Crossover,
+			Mutation:  operatorErrors.Mutation - errorsBefore.Mutation,
+		}
+		result.FinalPopulation = append([]Individual(nil), g.population.individuals...)
+		result.FinalFitness = append([]float64(nil), g.population.fitness...)
+		result.History = append([]GenerationStats(nil), g.history[historyStart:]...)
+		for _, observer := range g.observers {
+			observer.OnRunEnd(state.Generation, result.Best, reason)
+		}
 	}
 
-	return g.population.calculateBestIndividual()
+	g.terminationReason = ""
+	if withDiversity {
+		state.Diversity = g.population.diversity()
+	}
+	for _, observer := range g.observers {
+		observer.OnRunStart(g)
+	}
+	for {
+		state.Elapsed = time.Since(start)
+		state.Evaluations = g.population.evaluations - evaluationsBefore
+		if atomic.LoadInt32(&g.stopped) != 0 {
+			finish("stopped")
+			return result, nil
+		}
+		select {
+		case <-interrupts:
+			if err := g.SaveCheckpoint(g.checkpoint.path, g.checkpoint.codec); err != nil {
+				finish(err.Error())
+				return result, err
+			}
+			finish(ErrInterrupted.Error())
+			return result, ErrInterrupted
+		default:
+		}
+		if reason, ok := criterion.Check(state); ok {
+			finish(reason)
+			return result, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			finish(err.Error())
+			return result, err
+		}
+		for _, observer := range g.observers {
+			observer.OnGenerationStart(state.Generation + 1)
+		}
+		if err := g.population.evolve(ctx); err != nil {
+			finish(err.Error())
+			return result, err
+		}
+		state.Generation = g.population.generation
+
+		best := g.population.calculateBestIndividual()
+		stats := g.population.stats(withDiversity)
+		stats.Elapsed = time.Since(start)
+		state.Diversity = stats.Diversity
+		g.history = append(g.history, stats)
+		for _, observer := range g.observers {
+			observer.OnGenerationEnd(state.Generation, stats)
+		}
+		if fitness := g.population.calculateBestFitness(); g.population.objective.better(fitness, state.BestFitness) {
+			state.BestFitness = fitness
+			state.LastImprovement = state.Generation
+			g.best, g.bestFitness, g.bestGeneration = best, fitness, state.Generation
+			result.Best, result.BestFitness, result.BestGeneration = best, fitness, state.Generation
+			for _, observer := range g.observers {
+				observer.OnNewBest(state.Generation, best, fitness)
+			}
+		} else {
+			for _, observer := range g.observers {
+				observer.OnStagnation(state.Generation, state.Generation-state.LastImprovement)
+			}
+		}
+		for _, hook := range g.generationHooks {
+			hook(best)
+		}
+		if printIndividual != nil {
+			printIndividual(best)
+		}
+
+		if g.checkpoint != nil && state.Generation%g.checkpoint.every == 0 {
+			if err := g.SaveCheckpoint(g.checkpoint.path, g.checkpoint.codec); err != nil {
+				finish(err.Error())
+				return result, err
+			}
+		}
+	}
 }
 
-// maybe use builder pattern?
+// NewDefaultGA builds a GA with PermutationModel and the default elitism rate.
+//
+// Deprecated: use NewGA, which validates its parameters.
 func NewDefaultGA(generationNumber int, populationSize int, mutationRate float64, individual Individual) GA {
+	seed := newSeed()
 	return GA{
 		generationNumber: generationNumber,
 		population: Population{
-			mutationRate: mutationRate,
-			individuals:  generateInitialIndividuals(individual.GenerateIndividual, populationSize),
-			model:        PermutationModel{},
-			popSize:      populationSize,
+			crossoverRate: defaultCrossoverRate,
+			mutationRate:  mutationRate,
+			individuals:   generateInitialIndividuals(individual.GenerateIndividual, populationSize, seed),
+			model:         PermutationModel{},
+			popSize:       populationSize,
+			elitismRate:   defaultElitismRate,
+			seed:          seed,
 		},
 	}
 }
 
+// NewCustomGA builds a GA from positional parameters without checking them.
+//
+// Deprecated: use NewGA with WithModel, WithMutationRate and WithElitismRate.
 func NewCustomGA(generationNumber int, populationSize int, mutationRate float64, elitismRate float64, individual Individual, model Model) GA {
 	// model = createModel(modelType)
 	// --inside the createModel--
 	// return modelFactories[modelType]()
 	// eğer bir config dosyasından bir şeyler almam gerekse yukarıdaki fonksiyona parametre olarak yollayabilirim.
 
+	seed := newSeed()
 	return GA{
 		generationNumber: generationNumber,
 		population: Population{
+			crossoverRate:     defaultCrossoverRate,
 			mutationRate:      mutationRate,
-			individuals:       generateInitialIndividuals(individual.GenerateIndividual, populationSize),
+			individuals:       generateInitialIndividuals(individual.GenerateIndividual, populationSize, seed),
 			model:             model,
 			popSize:           populationSize,
 			totalFitnessScore: 0.0,
 			elitismRate:       elitismRate,
+			seed:              seed,
 		},
 	}
 }
 
-func generateInitialIndividuals(generateIndividual func() Individual, populationSize int) []Individual {
+func generateInitialIndividuals(generateIndividual func(rng *rand.Rand) Individual, populationSize int, seed int64) []Individual {
 	initialIndividuals := make([]Individual, populationSize)
-	for i := 0; i < populationSize; i++ {
-		initialIndividuals[i] = generateIndividual()
+	for start := 0; start < populationSize; start += chunkSize {
+		rng := deriveRand(seed, 0, start/chunkSize)
+		for i := start; i < min(start+chunkSize, populationSize); i++ {
+			initialIndividuals[i] = generateIndividual(rng)
+		}
 	}
 
 	return initialIndividuals
@@ -0,0 +1,66 @@
+package src
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// tour is a permutation whose fitness is the number of genes already in place.
+type tour []int
+
+func (t tour) CalculateFitness() float64 {
+	fitness := 0.0
+	for i, gene := range t {
+		if gene == i {
+			fitness++
+		}
+	}
+	return fitness
+}
+
+func (t tour) GenerateIndividual(rng *rand.Rand) Individual {
+	return tour(rng.Perm(30))
+}
+
+func newTestGA(t *testing.T, opts ...Option) *GA {
+	t.Helper()
+	opts = append([]Option{
+		WithPopulationSize(200),
+		WithGenerations(15),
+		WithSeed(42),
+		WithModel(ComposeModel[tour](OrderCrossover[tour], SwapMutation[tour]())),
+		WithSelector(TournamentSelection{Size: 3, Probability: 1}),
+	}, opts...)
+	ga, err := NewGA(tour{}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ga
+}
+
+func TestSameSeedSameResultForAnyConcurrency(t *testing.T) {
+	var want RunResult
+	for _, workers := range []int{1, 2, 3, 8} {
+		result, err := newTestGA(t, WithConcurrency(workers)).RunWithResult(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if workers == 1 {
+			want = result
+			continue
+		}
+
+		if !reflect.DeepEqual(result.FinalPopulation, want.FinalPopulation) {
+			t.Errorf("%d workers: final population differs from 1 worker", workers)
+		}
+		if !reflect.DeepEqual(result.FinalFitness, want.FinalFitness) {
+			t.Errorf("%d workers: final fitness differs from 1 worker", workers)
+		}
+		if result.BestFitness != want.BestFitness || result.BestGeneration != want.BestGeneration {
+			t.Errorf("%d workers: best %v in generation %d, want %v in generation %d",
+				workers, result.BestFitness, result.BestGeneration, want.BestFitness, want.BestGeneration)
+		}
+	}
+}
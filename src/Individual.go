@@ -1,6 +1,9 @@
 package src
 
+import "math/rand"
+
 type Individual interface {
 	CalculateFitness() float64
-	GenerateIndividual() Individual
+	// GenerateIndividual creates a random individual, drawing only from rng.
+	GenerateIndividual(rng *rand.Rand) Individual
 }
@@ -10,64 +10,118 @@ type PermutationModel struct {
 	DefaultModel
 }
 
-// Crossover is ordered crossover implementation
-func (pm PermutationModel) Crossover(parent1 Individual, parent2 Individual) (Individual, error) {
-	p1 := reflect.ValueOf(parent1)
-	p2 := reflect.ValueOf(parent2)
-	n := p1.Len()
-	child := reflect.MakeSlice(p1.Type(), n, n)
-
-	if p1.Kind() != reflect.Slice || p2.Kind() != reflect.Slice {
-		return nil, errors.New("parent(s) are not slice")
+// Crossover is ordered crossover implementation. It works on any Genome, see genomeOf.
+func (pm PermutationModel) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	p1, err := genomeOf(parent1)
+	if err != nil {
+		return nil, err
+	}
+	p2, err := genomeOf(parent2)
+	if err != nil {
+		return nil, err
 	}
 	if p1.Len() != p2.Len() {
-		return nil, errors.New("both slices must have the same length")
+		return nil, errors.New("both parents must have the same number of genes")
+	}
+	// The child starts as a copy of parent1; every position outside the segment is
+	// overwritten below.
+	n := p1.Len()
+	child, childGenes, err := cloneGenome(parent1)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return child, nil
 	}
 
-	bound1, bound2 := rand.Intn(n), rand.Intn(n)
+	bound1, bound2 := rng.Intn(n), rng.Intn(n)
 	if bound1 > bound2 {
 		bound1, bound2 = bound2, bound1
 	}
 
+	taken := newGeneSet(n)
 	for i := bound1; i < bound2; i++ {
-		child.Index(i).Set(p1.Index(i))
+		taken.add(p1.Get(i))
 	}
 
 	parent2Index := bound2
 	childIndex := bound2
 	for count := 0; count < n; count++ {
-		val := p2.Index(parent2Index % n).Interface()
-		if !contains(child, val) {
-			child.Index(childIndex % n).Set(p2.Index(parent2Index % n))
+		val := p2.Get(parent2Index % n)
+		if !taken.has(val) {
+			childGenes.Set(childIndex%n, val)
+			taken.add(val)
 			childIndex++
 		}
 		parent2Index++
 	}
 
-	return child.Interface().(Individual), nil
+	return child, nil
 }
 
-func contains(slice reflect.Value, item interface{}) bool {
-	for i := 0; i < slice.Len(); i++ {
-		if reflect.DeepEqual(slice.Index(i).Interface(), item) {
+// geneSet holds genes in a map when their type is comparable and falls back to
+// reflect.DeepEqual otherwise.
+type geneSet struct {
+	hashed map[interface{}]struct{}
+	others []interface{}
+}
+
+func newGeneSet(size int) *geneSet {
+	return &geneSet{hashed: make(map[interface{}]struct{}, size)}
+}
+
+func (gs *geneSet) add(gene interface{}) {
+	if reflect.TypeOf(gene).Comparable() {
+		gs.hashed[gene] = struct{}{}
+		return
+	}
+	gs.others = append(gs.others, gene)
+}
+
+func (gs *geneSet) has(gene interface{}) bool {
+	if reflect.TypeOf(gene).Comparable() {
+		_, ok := gs.hashed[gene]
+		return ok
+	}
+	for _, other := range gs.others {
+		if reflect.DeepEqual(other, gene) {
 			return true
 		}
 	}
 	return false
 }
 
-// Mutate is swap mutation implementation
-func (pm PermutationModel) Mutate(individual Individual) (Individual, error) {
-	individualReflection := reflect.ValueOf(individual)
-	genes := individualReflection.FieldByName("Genes")
-	if genes.Kind() != reflect.Slice {
-		return nil, errors.New("genes field is not a slice")
-	}
-	// Pick two random 2 genes and swap their positions
-	pos1, pos2 := rand.Intn(genes.Len()), rand.Intn(genes.Len())
-	tmp := genes.Index(pos1).Interface()
-	genes.Index(pos1).Set(genes.Index(pos2))
-	genes.Index(pos2).Set(reflect.ValueOf(tmp))
+// Mutate is swap mutation implementation. It works on any Genome, see genomeOf.
+func (pm PermutationModel) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	mutated, genes, err := cloneGenome(individual)
+	if err != nil {
+		return nil, err
+	}
+	if genes.Len() < 2 {
+		return mutated, nil
+	}
+	// Pick two different random genes and swap their positions
+	genes.Swap(distinctPositions(genes.Len(), rng))
+
+	return mutated, nil
+}
+
+// MutateGenes swaps every gene with probability rate with a gene at another random position.
+func (pm PermutationModel) MutateGenes(individual Individual, rate float64, rng *rand.Rand) (Individual, int, error) {
+	mutated, genes, err := cloneGenome(individual)
+	if err != nil {
+		return nil, 0, err
+	}
+	if genes.Len() < 2 {
+		return mutated, 0, nil
+	}
+	count := 0
+	for locus := 0; locus < genes.Len(); locus++ {
+		if rng.Float64() < rate {
+			genes.Swap(locus, otherPosition(genes.Len(), locus, rng))
+			count++
+		}
+	}
 
-	return individualReflection.Interface().(Individual), nil
+	return mutated, count, nil
 }
@@ -0,0 +1,46 @@
+package src
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AlleleProvider is implemented by individuals whose genes take values from a known set.
+// DefaultModel.Mutate needs it.
+type AlleleProvider interface {
+	// Alleles returns the values the gene at locus may take, as a slice of the gene type.
+	// Individuals with one shared alphabet return the same slice for every locus. For
+	// CreepMutation the slice must be ordered.
+	Alleles(locus int) interface{}
+}
+
+// AlleleMutation selects how DefaultModel.Mutate changes an individual.
+type AlleleMutation int
+
+const (
+	// RandomReset sets one random gene to a random allele.
+	RandomReset AlleleMutation = iota
+	// CreepMutation moves one random gene to a nearby allele, at most CreepStep positions
+	// away in its ordered allele list.
+	CreepMutation
+)
+
+func allelesAt(provider AlleleProvider, locus int) (reflect.Value, error) {
+	alleles := reflect.ValueOf(provider.Alleles(locus))
+	if alleles.Kind() != reflect.Slice {
+		return reflect.Value{}, errors.New("alleles must be a slice")
+	}
+	if alleles.Len() == 0 {
+		return reflect.Value{}, errors.New("no alleles for a locus")
+	}
+	return alleles, nil
+}
+
+func indexOfAllele(alleles reflect.Value, gene interface{}) int {
+	for i := 0; i < alleles.Len(); i++ {
+		if reflect.DeepEqual(alleles.Index(i).Interface(), gene) {
+			return i
+		}
+	}
+	return -1
+}
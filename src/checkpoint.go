@@ -0,0 +1,234 @@
+package src
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+const checkpointVersion = 3
+
+// ErrInterrupted is returned by a run that was stopped by SIGINT after saving a checkpoint.
+var ErrInterrupted = errors.New("run interrupted")
+
+// Codec turns individuals into bytes and back for checkpoints.
+type Codec interface {
+	Marshal(individual Individual) ([]byte, error)
+	Unmarshal(data []byte) (Individual, error)
+}
+
+// JSONCodec encodes individuals as JSON. Prototype gives the concrete type to decode into.
+type JSONCodec struct {
+	Prototype Individual
+}
+
+func (jc JSONCodec) Marshal(individual Individual) ([]byte, error) {
+	return json.Marshal(individual)
+}
+
+func (jc JSONCodec) Unmarshal(data []byte) (Individual, error) {
+	ptr, err := newPrototype(jc.Prototype)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface().(Individual), nil
+}
+
+// GobCodec encodes individuals with encoding/gob. Prototype gives the concrete type to decode into.
+type GobCodec struct {
+	Prototype Individual
+}
+
+func (gc GobCodec) Marshal(individual Individual) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(individual); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gc GobCodec) Unmarshal(data []byte) (Individual, error) {
+	ptr, err := newPrototype(gc.Prototype)
+	if err != nil {
+		return nil, err
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface().(Individual), nil
+}
+
+func newPrototype(prototype Individual) (reflect.Value, error) {
+	if prototype == nil {
+		return reflect.Value{}, errors.New("codec has no prototype")
+	}
+	return reflect.New(reflect.TypeOf(prototype)), nil
+}
+
+type checkpointConfig struct {
+	path  string
+	codec Codec
+	every int
+}
+
+// checkpoint is the file format of a saved run. The random sources are derived from the
+// seed and the generation, so together they are the whole RNG state.
+type checkpoint struct {
+	Version          int
+	Seed             int64
+	Generation       int
+	Evaluations      int
+	GenerationNumber int
+	RemainingRun     int // generations the run in progress had left, 0 between runs
+	PopulationSize   int
+	CrossoverRate    float64
+	MutationRate     float64
+	GeneMutationRate float64
+	ElitismRate      float64
+	Objective        Objective
+	Individuals      [][]byte
+	Fitness          []float64
+	Best             []byte
+	BestFitness      float64
+	BestGeneration   int
+	History          []GenerationStats
+}
+
+// SaveCheckpoint writes the state of the GA to path, encoding individuals with codec.
+// The file is replaced atomically, so an interrupted save keeps the previous checkpoint.
+func (g *GA) SaveCheckpoint(path string, codec Codec) error {
+	g.population.ensureEvaluated()
+	cp := checkpoint{
+		Version:          checkpointVersion,
+		Seed:             g.population.seed,
+		Generation:       g.population.generation,
+		Evaluations:      g.population.evaluations,
+		GenerationNumber: g.generationNumber,
+		RemainingRun:     max(g.runEnd-g.population.generation, 0),
+		PopulationSize:   g.population.popSize,
+		CrossoverRate:    g.population.crossoverRate,
+		MutationRate:     g.population.mutationRate,
+		GeneMutationRate: g.population.geneMutationRate,
+		ElitismRate:      g.population.elitismRate,
+		Objective:        g.population.objective,
+		Individuals:      make([][]byte, len(g.population.individuals)),
+		Fitness:          g.population.fitness,
+		BestFitness:      g.bestFitness,
+		BestGeneration:   g.bestGeneration,
+		History:          g.history,
+	}
+	for i, individual := range g.population.individuals {
+		data, err := codec.Marshal(individual)
+		if err != nil {
+			return fmt.Errorf("encode individual %d: %w", i, err)
+		}
+		cp.Individuals[i] = data
+	}
+	if g.best != nil {
+		data, err := codec.Marshal(g.best)
+		if err != nil {
+			return fmt.Errorf("encode best individual: %w", err)
+		}
+		cp.Best = data
+	}
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// CheckpointCodec is the codec given to WithCheckpoint, or nil when the GA saves no
+// checkpoints.
+func (g *GA) CheckpointCodec() Codec {
+	if g.checkpoint == nil {
+		return nil
+	}
+	return g.checkpoint.codec
+}
+
+// ResumeGA rebuilds a GA from a checkpoint written by SaveCheckpoint. Population, rates,
+// generation counter, seed and history come from the file; everything that cannot be
+// saved, such as the model, observers and termination criteria, comes from opts.
+// Running the resumed GA continues the saved run exactly, up to the generation it would
+// have stopped at.
+func ResumeGA(path string, codec Codec, opts ...Option) (*GA, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	if cp.Version != checkpointVersion {
+		return nil, fmt.Errorf("checkpoint %s has version %d, want %d", path, cp.Version, checkpointVersion)
+	}
+	if len(cp.Individuals) != len(cp.Fitness) {
+		return nil, fmt.Errorf("checkpoint %s has %d individuals but %d fitness values", path, len(cp.Individuals), len(cp.Fitness))
+	}
+
+	c := newConfig(opts)
+	c.generationNumber = cp.GenerationNumber
+	c.populationSize = cp.PopulationSize
+	c.crossoverRate = cp.CrossoverRate
+	c.mutationRate = cp.MutationRate
+	c.geneMutationRate = cp.GeneMutationRate
+	c.elitismRate = cp.ElitismRate
+	c.objective = cp.Objective
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	individuals := make([]Individual, len(cp.Individuals))
+	for i, encoded := range cp.Individuals {
+		individual, err := codec.Unmarshal(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode individual %d: %w", i, err)
+		}
+		individuals[i] = individual
+	}
+
+	g := c.newGA(cp.Seed, individuals)
+	g.population.fitness = cp.Fitness
+	g.population.generation = cp.Generation
+	g.population.evaluations = cp.Evaluations
+	g.population.refreshTotals()
+	if cp.RemainingRun > 0 {
+		g.runEnd = cp.Generation + cp.RemainingRun
+	}
+	g.history = cp.History
+	if cp.Best != nil {
+		best, err := codec.Unmarshal(cp.Best)
+		if err != nil {
+			return nil, fmt.Errorf("decode best individual: %w", err)
+		}
+		g.best = best
+		g.bestFitness = cp.BestFitness
+		g.bestGeneration = cp.BestGeneration
+	}
+
+	return g, nil
+}
@@ -0,0 +1,48 @@
+package src
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResumedRunMatchesUninterruptedRun(t *testing.T) {
+	want, err := newTestGA(t).RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, codec := range []Codec{JSONCodec{Prototype: tour{}}, GobCodec{Prototype: tour{}}} {
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		// The run stops right after saving the checkpoint of generation 6.
+		interrupted := newTestGA(t, WithCheckpoint(path, codec, 6), WithTermination(MaxGenerations(6)))
+		if _, err := interrupted.RunWithResult(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		resumed, err := ResumeGA(path, codec,
+			WithModel(ComposeModel[tour](OrderCrossover[tour], SwapMutation[tour]())),
+			WithSelector(TournamentSelection{Size: 3, Probability: 1}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := resumed.RunWithResult(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Generations != want.Generations {
+			t.Fatalf("%T: resumed run reached generation %d, want %d", codec, got.Generations, want.Generations)
+		}
+		if !reflect.DeepEqual(got.FinalPopulation, want.FinalPopulation) {
+			t.Errorf("%T: final population differs from the uninterrupted run", codec)
+		}
+		if !reflect.DeepEqual(got.FinalFitness, want.FinalFitness) {
+			t.Errorf("%T: final fitness differs from the uninterrupted run", codec)
+		}
+		if got.BestFitness != want.BestFitness || got.BestGeneration != want.BestGeneration {
+			t.Errorf("%T: best %v in generation %d, want %v in generation %d",
+				codec, got.BestFitness, got.BestGeneration, want.BestFitness, want.BestGeneration)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package src
+
+import "reflect"
+
+// Cloner is implemented by individuals that know how to copy themselves. Clone must return
+// an individual that shares no mutable memory with the original. Individuals that are not
+// Cloners are copied with DeepCopy.
+type Cloner interface {
+	Clone() Individual
+}
+
+// cloneIndividual gives the engine a copy it owns, for offspring that would otherwise share
+// their genes with a parent.
+func cloneIndividual(individual Individual) Individual {
+	if cloner, ok := individual.(Cloner); ok {
+		return cloner.Clone()
+	}
+	return DeepCopy(individual)
+}
+
+// sharesGenes reports whether child and parent refer to the same memory, as when Crossover
+// hands back a parent or a part of it. The backing arrays of slices, pointed-to values and
+// maps are compared, in the individuals themselves and in their fields, exported or not,
+// so struct Genomes and wrappers around slices are covered too.
+func sharesGenes(child Individual, parent Individual) bool {
+	childSpans := memorySpans(reflect.ValueOf(child), nil, nil)
+	if len(childSpans) == 0 {
+		return false
+	}
+	parentSpans := memorySpans(reflect.ValueOf(parent), nil, nil)
+	for _, c := range childSpans {
+		for _, p := range parentSpans {
+			if c.start < p.end && p.start < c.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// memorySpan is a block of memory [start, end) that a value refers to.
+type memorySpan struct {
+	start uintptr
+	end   uintptr
+}
+
+// memorySpans appends the memory value refers to through slices, pointers and maps. The
+// elements of slices and maps are not followed; visited guards against pointer cycles.
+func memorySpans(value reflect.Value, spans []memorySpan, visited map[uintptr]bool) []memorySpan {
+	switch value.Kind() {
+	case reflect.Slice:
+		if size := value.Type().Elem().Size(); value.Len() > 0 && size > 0 {
+			start := value.Pointer()
+			spans = append(spans, memorySpan{start, start + uintptr(value.Len())*size})
+		}
+	case reflect.Map:
+		if !value.IsNil() {
+			spans = append(spans, memorySpan{value.Pointer(), value.Pointer() + 1})
+		}
+	case reflect.Pointer:
+		if value.IsNil() || visited[value.Pointer()] {
+			break
+		}
+		if visited == nil {
+			visited = make(map[uintptr]bool)
+		}
+		start := value.Pointer()
+		visited[start] = true
+		spans = append(spans, memorySpan{start, start + max(value.Type().Elem().Size(), 1)})
+		spans = memorySpans(value.Elem(), spans, visited)
+	case reflect.Interface:
+		if !value.IsNil() {
+			spans = memorySpans(value.Elem(), spans, visited)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			spans = memorySpans(value.Field(i), spans, visited)
+		}
+	case reflect.Array:
+		if needsDeepCopy(value.Type().Elem()) {
+			for i := 0; i < value.Len(); i++ {
+				spans = memorySpans(value.Index(i), spans, visited)
+			}
+		}
+	}
+	return spans
+}
+
+// DeepCopy copies value with reflection, following slices, arrays, maps, pointers,
+// interfaces and exported struct fields. Unexported struct fields, functions and channels
+// are copied shallowly. value must not contain pointer cycles.
+func DeepCopy[T any](value T) T {
+	clone, _ := deepCopy(reflect.ValueOf(&value).Elem()).Interface().(T)
+	return clone
+}
+
+func deepCopy(value reflect.Value) reflect.Value {
+	if !needsDeepCopy(value.Type()) {
+		return value
+	}
+
+	clone := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		clone.Set(reflect.MakeSlice(value.Type(), value.Len(), value.Len()))
+		if !needsDeepCopy(value.Type().Elem()) {
+			reflect.Copy(clone, value)
+			break
+		}
+		for i := 0; i < value.Len(); i++ {
+			clone.Index(i).Set(deepCopy(value.Index(i)))
+		}
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			clone.Index(i).Set(deepCopy(value.Index(i)))
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		clone.Set(reflect.MakeMapWithSize(value.Type(), value.Len()))
+		iter := value.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		clone.Set(reflect.New(value.Type().Elem()))
+		clone.Elem().Set(deepCopy(value.Elem()))
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		clone.Set(deepCopy(value.Elem()))
+	case reflect.Struct:
+		clone.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				clone.Field(i).Set(deepCopy(value.Field(i)))
+			}
+		}
+	}
+	return clone
+}
+
+// needsDeepCopy reports whether values of type t may refer to memory that an assignment
+// does not copy.
+func needsDeepCopy(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface:
+		return true
+	case reflect.Array:
+		return needsDeepCopy(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && needsDeepCopy(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
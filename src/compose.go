@@ -0,0 +1,197 @@
+package src
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Mutator changes individuals of type T.
+type Mutator[T any] interface {
+	Mutate(individual T, rng *rand.Rand) (T, error)
+}
+
+// GeneMutator is a Mutator that can also mutate every gene independently, see
+// WithGeneMutationRate. MutateGenes returns how many genes it mutated.
+type GeneMutator[T any] interface {
+	Mutator[T]
+	MutateGenes(individual T, rate float64, rng *rand.Rand) (T, int, error)
+}
+
+// MutationOperator changes an individual of type T. It turns a plain function into a
+// Mutator.
+type MutationOperator[T any] func(individual T, rng *rand.Rand) (T, error)
+
+func (op MutationOperator[T]) Mutate(individual T, rng *rand.Rand) (T, error) {
+	return op(individual, rng)
+}
+
+// ComposeModel builds a Model from typed operators, without a Model type of your own.
+// Parents are selected like DefaultModel does, or by the Selector given to WithSelector.
+// A nil crossover falls back to DefaultModel.Crossover, and a nil mutation leaves
+// offspring unchanged. Per-gene mutation needs a GeneMutator, such as the built-in
+// mutations.
+func ComposeModel[T Individual](crossover CrossoverOperator[T], mutation Mutator[T]) Model {
+	return composedModel[T]{crossover: crossover, mutation: mutation}
+}
+
+type composedModel[T Individual] struct {
+	DefaultModel
+	crossover CrossoverOperator[T]
+	mutation  Mutator[T]
+}
+
+func (cm composedModel[T]) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	if cm.crossover == nil {
+		return cm.DefaultModel.Crossover(parent1, parent2, rng)
+	}
+
+	p1, ok1 := parent1.(T)
+	p2, ok2 := parent2.(T)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("parents are %T and %T, not %T", parent1, parent2, *new(T))
+	}
+	return cm.crossover(p1, p2, rng)
+}
+
+func (cm composedModel[T]) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	if cm.mutation == nil {
+		return individual, nil
+	}
+
+	typed, ok := individual.(T)
+	if !ok {
+		return nil, fmt.Errorf("individual is %T, not %T", individual, *new(T))
+	}
+	return cm.mutation.Mutate(typed, rng)
+}
+
+func (cm composedModel[T]) SupportsGeneMutation() bool {
+	return SupportsGeneMutation(cm.mutation)
+}
+
+// SupportsGeneMutation reports whether mutation, which may be nil, can mutate per gene:
+// it must be a GeneMutator and, when it has a SupportsGeneMutation method, report true.
+// A MutateGenes promoted from an embedded field needs that method, see GeneMutationModel.
+func SupportsGeneMutation[T any](mutation Mutator[T]) bool {
+	if mutation == nil {
+		return true
+	}
+	_, ok := mutation.(GeneMutator[T])
+	return supportsGeneMutation(mutation, ok)
+}
+
+func (cm composedModel[T]) MutateGenes(individual Individual, rate float64, rng *rand.Rand) (Individual, int, error) {
+	if cm.mutation == nil {
+		return individual, 0, nil
+	}
+
+	geneMutator, ok := cm.mutation.(GeneMutator[T])
+	if !ok {
+		return nil, 0, fmt.Errorf("%T does not support per-gene mutation", cm.mutation)
+	}
+	typed, ok := individual.(T)
+	if !ok {
+		return nil, 0, fmt.Errorf("individual is %T, not %T", individual, *new(T))
+	}
+	return geneMutator.MutateGenes(typed, rate, rng)
+}
+
+// ComposePairModel is ComposeModel with a crossover that returns two children, both of
+// which are kept. See PairCrossoverModel.
+func ComposePairModel[T Individual](crossover PairCrossoverOperator[T], mutation Mutator[T]) Model {
+	return pairComposedModel[T]{composedModel: composedModel[T]{mutation: mutation}, pair: crossover}
+}
+
+type pairComposedModel[T Individual] struct {
+	composedModel[T]
+	pair PairCrossoverOperator[T]
+}
+
+func (pm pairComposedModel[T]) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	child, _, err := pm.CrossoverPair(parent1, parent2, rng)
+	return child, err
+}
+
+func (pm pairComposedModel[T]) CrossoverPair(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, Individual, error) {
+	p1, ok1 := parent1.(T)
+	p2, ok2 := parent2.(T)
+	if !ok1 || !ok2 {
+		return nil, nil, fmt.Errorf("parents are %T and %T, not %T", parent1, parent2, *new(T))
+	}
+	return pm.pair(p1, p2, rng)
+}
+
+// ComposeGenomeModel is ComposeModel for individuals of any shape whose genes have type G,
+// such as structs that implement Genome. The operators work on []G copies of the genes,
+// so every slice operator of this package can be used; the offspring is a copy of the
+// first parent that holds the new genes.
+func ComposeGenomeModel[G any](crossover CrossoverOperator[[]G], mutation Mutator[[]G]) Model {
+	return genomeComposedModel[G]{crossover: crossover, mutation: mutation}
+}
+
+type genomeComposedModel[G any] struct {
+	DefaultModel
+	crossover CrossoverOperator[[]G]
+	mutation  Mutator[[]G]
+}
+
+func (gm genomeComposedModel[G]) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	if gm.crossover == nil {
+		return gm.DefaultModel.Crossover(parent1, parent2, rng)
+	}
+
+	genes1, err := genesOf[G](parent1)
+	if err != nil {
+		return nil, err
+	}
+	genes2, err := genesOf[G](parent2)
+	if err != nil {
+		return nil, err
+	}
+	child, err := gm.crossover(genes1, genes2, rng)
+	if err != nil {
+		return nil, err
+	}
+	return withGenes(parent1, child)
+}
+
+func (gm genomeComposedModel[G]) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	if gm.mutation == nil {
+		return individual, nil
+	}
+
+	genes, err := genesOf[G](individual)
+	if err != nil {
+		return nil, err
+	}
+	mutated, err := gm.mutation.Mutate(genes, rng)
+	if err != nil {
+		return nil, err
+	}
+	return withGenes(individual, mutated)
+}
+
+func (gm genomeComposedModel[G]) SupportsGeneMutation() bool {
+	return SupportsGeneMutation(gm.mutation)
+}
+
+func (gm genomeComposedModel[G]) MutateGenes(individual Individual, rate float64, rng *rand.Rand) (Individual, int, error) {
+	if gm.mutation == nil {
+		return individual, 0, nil
+	}
+
+	geneMutator, ok := gm.mutation.(GeneMutator[[]G])
+	if !ok {
+		return nil, 0, fmt.Errorf("%T does not support per-gene mutation", gm.mutation)
+	}
+	genes, err := genesOf[G](individual)
+	if err != nil {
+		return nil, 0, err
+	}
+	mutated, count, err := geneMutator.MutateGenes(genes, rate, rng)
+	if err != nil {
+		return nil, 0, err
+	}
+	mutatedIndividual, err := withGenes(individual, mutated)
+	return mutatedIndividual, count, err
+}
@@ -0,0 +1,321 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+var (
+	errLengthMismatch = errors.New("both slices must have the same length")
+	errNoCutPoints    = errors.New("k-point crossover needs at least one cut point")
+)
+
+// CrossoverOperator recombines two parents of type T into a new child. The operators in
+// this file work on slice genomes, never modify their parents and never return a child
+// that shares memory with them.
+type CrossoverOperator[T any] func(parent1 T, parent2 T, rng *rand.Rand) (T, error)
+
+// PairCrossoverOperator recombines two parents into both of the children a crossover
+// naturally produces, so that neither has to be thrown away. See ComposePairModel.
+type PairCrossoverOperator[T any] func(parent1 T, parent2 T, rng *rand.Rand) (T, T, error)
+
+// Pair turns any crossover into a PairCrossoverOperator by recombining the parents twice,
+// the second time with their roles swapped. The two children are independent; the pair
+// versions of the built-in crossovers return complementary children instead.
+func Pair[T any](crossover CrossoverOperator[T]) PairCrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, T, error) {
+		child1, err := crossover(parent1, parent2, rng)
+		if err != nil {
+			return child1, child1, err
+		}
+		child2, err := crossover(parent2, parent1, rng)
+		return child1, child2, err
+	}
+}
+
+// maskChild takes the genes marked in fromParent2 from parent2 and the rest from parent1.
+func maskChild[T ~[]G, G any](parent1 T, parent2 T, fromParent2 []bool) T {
+	child := make(T, len(parent1))
+	for i := range child {
+		if fromParent2[i] {
+			child[i] = parent2[i]
+		} else {
+			child[i] = parent1[i]
+		}
+	}
+	return child
+}
+
+// OnePointCrossover copies genes before a random point from parent1 and the rest from parent2.
+func OnePointCrossover[T ~[]G, G any](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return KPointCrossover[T](1)(parent1, parent2, rng)
+}
+
+// TwoPointCrossover copies the genes between two random points from parent2 and the rest
+// from parent1.
+func TwoPointCrossover[T ~[]G, G any](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return KPointCrossover[T](2)(parent1, parent2, rng)
+}
+
+// KPointCrossover cuts the parents at k distinct random points and takes the segments
+// alternately from parent1 and parent2. k is capped at the number of possible cut points;
+// k below 1 is an error.
+func KPointCrossover[T ~[]G, G any](k int) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+		if k < 1 {
+			return nil, errNoCutPoints
+		}
+
+		return maskChild(parent1, parent2, kPointMask(len(parent1), k, rng)), nil
+	}
+}
+
+// KPointCrossoverPair is KPointCrossover returning both children: the second one takes
+// its segments from the parents the other way round.
+func KPointCrossoverPair[T ~[]G, G any](k int) PairCrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, nil, errLengthMismatch
+		}
+		if k < 1 {
+			return nil, nil, errNoCutPoints
+		}
+
+		mask := kPointMask(len(parent1), k, rng)
+		return maskChild(parent1, parent2, mask), maskChild(parent2, parent1, mask), nil
+	}
+}
+
+// kPointMask marks the genes taken from parent2 when n genes are cut at k points.
+func kPointMask(n int, k int, rng *rand.Rand) []bool {
+	cut := make([]bool, n)
+	if n > 1 {
+		for _, point := range rng.Perm(n - 1)[:min(k, n-1)] {
+			cut[point+1] = true
+		}
+	}
+
+	fromParent2 := make([]bool, n)
+	for i := 0; i < n; i++ {
+		fromParent2[i] = cut[i] != (i > 0 && fromParent2[i-1])
+	}
+	return fromParent2
+}
+
+// UniformCrossover takes every gene from parent1 with probability bias and from parent2
+// otherwise. Bias 0.5 is the classic uniform crossover.
+func UniformCrossover[T ~[]G, G any](bias float64) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+
+		return maskChild(parent1, parent2, uniformMask(len(parent1), bias, rng)), nil
+	}
+}
+
+// UniformCrossoverPair is UniformCrossover returning both children: the second one takes
+// every gene from the parent the first one did not.
+func UniformCrossoverPair[T ~[]G, G any](bias float64) PairCrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, nil, errLengthMismatch
+		}
+
+		mask := uniformMask(len(parent1), bias, rng)
+		return maskChild(parent1, parent2, mask), maskChild(parent2, parent1, mask), nil
+	}
+}
+
+// uniformMask marks every gene for parent2 with probability 1-bias.
+func uniformMask(n int, bias float64, rng *rand.Rand) []bool {
+	fromParent2 := make([]bool, n)
+	for i := range fromParent2 {
+		fromParent2[i] = !(rng.Float64() < bias)
+	}
+	return fromParent2
+}
+
+// ShuffleCrossover applies one-point crossover to both parents after shuffling their
+// genes the same way, then restores the original gene order. It removes the positional
+// bias of one-point crossover.
+func ShuffleCrossover[T ~[]G, G any](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	if len(parent1) != len(parent2) {
+		return nil, errLengthMismatch
+	}
+
+	n := len(parent1)
+	order := rng.Perm(n)
+	crossoverPoint := rng.Intn(n + 1)
+	child := make(T, n)
+	for i, position := range order {
+		if i < crossoverPoint {
+			child[position] = parent1[position]
+		} else {
+			child[position] = parent2[position]
+		}
+	}
+	return child, nil
+}
+
+// HalfUniformCrossover (HUX) starts from parent1 and takes exactly half of the genes in
+// which the parents differ, chosen at random, from parent2.
+func HalfUniformCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	if len(parent1) != len(parent2) {
+		return nil, errLengthMismatch
+	}
+
+	child := make(T, len(parent1))
+	copy(child, parent1)
+	var differing []int
+	for i := range parent1 {
+		if parent1[i] != parent2[i] {
+			differing = append(differing, i)
+		}
+	}
+	rng.Shuffle(len(differing), func(i, j int) { differing[i], differing[j] = differing[j], differing[i] })
+	for _, i := range differing[:len(differing)/2] {
+		child[i] = parent2[i]
+	}
+	return child, nil
+}
+
+// SimulatedBinaryCrossoverPair is SBX returning both of its children.
+func SimulatedBinaryCrossoverPair[T ~[]float64](eta float64) PairCrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, nil, errLengthMismatch
+		}
+
+		child1, child2 := make(T, len(parent1)), make(T, len(parent1))
+		for i := range child1 {
+			beta := sbxBeta(eta, rng)
+			child1[i] = 0.5 * ((1+beta)*parent1[i] + (1-beta)*parent2[i])
+			child2[i] = 0.5 * ((1-beta)*parent1[i] + (1+beta)*parent2[i])
+		}
+		return child1, child2, nil
+	}
+}
+
+func sbxBeta(eta float64, rng *rand.Rand) float64 {
+	u := rng.Float64()
+	if u <= 0.5 {
+		return math.Pow(2*u, 1/(eta+1))
+	}
+	return math.Pow(1/(2*(1-u)), 1/(eta+1))
+}
+
+// ArithmeticCrossover is the weighted mean alpha*parent1 + (1-alpha)*parent2 of
+// real-valued parents.
+func ArithmeticCrossover[T ~[]float64](alpha float64) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+
+		child := make(T, len(parent1))
+		for i := range child {
+			child[i] = alpha*parent1[i] + (1-alpha)*parent2[i]
+		}
+		return child, nil
+	}
+}
+
+// BlendCrossover is BLX-alpha: every gene is drawn uniformly from the interval spanned by
+// the parents' genes, widened by alpha times its length on both sides.
+func BlendCrossover[T ~[]float64](alpha float64) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+
+		child := make(T, len(parent1))
+		for i := range child {
+			low, high := math.Min(parent1[i], parent2[i]), math.Max(parent1[i], parent2[i])
+			spread := alpha * (high - low)
+			child[i] = low - spread + rng.Float64()*(high-low+2*spread)
+		}
+		return child, nil
+	}
+}
+
+// SimulatedBinaryCrossover is SBX with distribution index eta: the child's genes spread
+// around the parents' genes like single-point crossover does on binary strings. Larger
+// eta keeps children closer to their parents. Of the two SBX children, every gene is
+// taken from one at random.
+func SimulatedBinaryCrossover[T ~[]float64](eta float64) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+
+		child := make(T, len(parent1))
+		for i := range child {
+			beta := sbxBeta(eta, rng)
+			if rng.Intn(2) == 0 {
+				child[i] = 0.5 * ((1+beta)*parent1[i] + (1-beta)*parent2[i])
+			} else {
+				child[i] = 0.5 * ((1-beta)*parent1[i] + (1+beta)*parent2[i])
+			}
+		}
+		return child, nil
+	}
+}
+
+// LinearCrossover is Wright's linear crossover. Of its three candidates (p1+p2)/2,
+// 1.5p1-0.5p2 and -0.5p1+1.5p2, one is returned at random since operators do not see
+// fitness.
+func LinearCrossover[T ~[]float64](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	if len(parent1) != len(parent2) {
+		return nil, errLengthMismatch
+	}
+
+	weights := [3][2]float64{{0.5, 0.5}, {1.5, -0.5}, {-0.5, 1.5}}[rng.Intn(3)]
+	child := make(T, len(parent1))
+	for i := range child {
+		child[i] = weights[0]*parent1[i] + weights[1]*parent2[i]
+	}
+	return child, nil
+}
+
+// CrossoverByName returns a crossover for slice genomes with its usual parameters:
+// "one-point", "two-point", "uniform" (bias 0.5), "shuffle" or "hux".
+func CrossoverByName[T ~[]G, G comparable](name string) (CrossoverOperator[T], error) {
+	switch name {
+	case "one-point":
+		return OnePointCrossover[T], nil
+	case "two-point":
+		return TwoPointCrossover[T], nil
+	case "uniform":
+		return UniformCrossover[T](0.5), nil
+	case "shuffle":
+		return ShuffleCrossover[T], nil
+	case "hux":
+		return HalfUniformCrossover[T], nil
+	default:
+		return nil, fmt.Errorf("unknown crossover %q", name)
+	}
+}
+
+// RealCrossoverByName is CrossoverByName for real-valued genomes. Besides the names
+// CrossoverByName knows, it accepts "arithmetic" (alpha 0.5), "blx-alpha" (alpha 0.5),
+// "sbx" (eta 15) and "linear".
+func RealCrossoverByName[T ~[]float64](name string) (CrossoverOperator[T], error) {
+	switch name {
+	case "arithmetic":
+		return ArithmeticCrossover[T](0.5), nil
+	case "blx-alpha":
+		return BlendCrossover[T](0.5), nil
+	case "sbx":
+		return SimulatedBinaryCrossover[T](15), nil
+	case "linear":
+		return LinearCrossover[T], nil
+	default:
+		return CrossoverByName[T](name)
+	}
+}
@@ -0,0 +1,30 @@
+package src
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKPointCrossoverRejectsFewerThanOneCutPoint(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	parent1, parent2 := tour{0, 1, 2, 3}, tour{3, 2, 1, 0}
+	for _, k := range []int{-1, 0} {
+		if _, err := KPointCrossover[tour](k)(parent1, parent2, rng); err == nil {
+			t.Errorf("KPointCrossover(%d) returned no error", k)
+		}
+		if _, _, err := KPointCrossoverPair[tour](k)(parent1, parent2, rng); err == nil {
+			t.Errorf("KPointCrossoverPair(%d) returned no error", k)
+		}
+	}
+	for _, k := range []int{1, 3, 10} {
+		child, err := KPointCrossover[tour](k)(parent1, parent2, rng)
+		if err != nil {
+			t.Fatalf("KPointCrossover(%d): %v", k, err)
+		}
+		for i, gene := range child {
+			if gene != parent1[i] && gene != parent2[i] {
+				t.Errorf("KPointCrossover(%d) returned %v, whose gene %d is in neither parent", k, child, i)
+			}
+		}
+	}
+}
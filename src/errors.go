@@ -0,0 +1,40 @@
+package src
+
+import (
+	"fmt"
+)
+
+// ErrorPolicy decides what happens when Crossover or Mutate returns an error.
+type ErrorPolicy int
+
+const (
+	// AbortOnError stops the run and returns the operator error.
+	AbortOnError ErrorPolicy = iota
+	// RetryOnError selects new parents and breeds again. The run is aborted when
+	// maxOperatorRetries retries fail as well.
+	RetryOnError
+	// FallbackOnError uses a copy of the first parent as the offspring.
+	FallbackOnError
+)
+
+const maxOperatorRetries = 10
+
+// OperatorError wraps an error returned by a Model operator.
+type OperatorError struct {
+	Operator string
+	Err      error
+}
+
+func (e *OperatorError) Error() string {
+	return fmt.Sprintf("%s failed: %v", e.Operator, e.Err)
+}
+
+func (e *OperatorError) Unwrap() error {
+	return e.Err
+}
+
+// OperatorErrorCounts is the number of errors each operator returned during a run.
+type OperatorErrorCounts struct {
+	Crossover int
+	Mutation  int
+}
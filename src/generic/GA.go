@@ -0,0 +1,219 @@
+// Package generic is a type-parameterized front end to the src package. Individuals and
+// operators keep their own type, so results need no type assertion and slice operators
+// need no reflection.
+package generic
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
+)
+
+// Individual is the typed counterpart of src.Individual. Crossover must return children
+// that do not share genes with the parents, as the built-in operators do. Parents that
+// become offspring unchanged, when crossover is skipped or fails under
+// src.FallbackOnError, are copied with their Clone() T method when they have one and with
+// src.DeepCopy otherwise.
+type Individual[T any] interface {
+	CalculateFitness() float64
+	// GenerateIndividual creates a random individual, drawing only from rng.
+	GenerateIndividual(rng *rand.Rand) T
+}
+
+// Model holds the typed crossover and mutation operators. Parents are selected by the
+// engine with fitness proportionate selection. Per-gene mutation, see
+// src.WithGeneMutationRate, needs a model that is also a src.GeneMutator.
+type Model[T any] interface {
+	Crossover(parent1 T, parent2 T, rng *rand.Rand) (T, error)
+	Mutate(individual T, rng *rand.Rand) (T, error)
+}
+
+// PairModel is a Model whose crossover can also return both children. NewGA then keeps
+// both, see src.PairCrossoverModel.
+type PairModel[T any] interface {
+	Model[T]
+	CrossoverPair(parent1 T, parent2 T, rng *rand.Rand) (T, T, error)
+}
+
+type GA[T Individual[T]] struct {
+	ga *src.GA
+}
+
+// NewGA builds a GA that breeds individuals like the given one with model. The options are
+// the ones of src.NewGA; a src.WithModel option is ignored in favour of model. Checkpoints,
+// observers and generation hooks that see individuals must be set with WithCheckpoint,
+// WithObserver and WithGenerationHook of this package.
+func NewGA[T Individual[T]](individual T, model Model[T], opts ...src.Option) (*GA[T], error) {
+	opts = append(opts, src.WithModel(adaptModel(model)))
+	ga, err := src.NewGA(individualAdapter[T]{value: individual}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGA[T](ga)
+}
+
+func newGA[T Individual[T]](ga *src.GA) (*GA[T], error) {
+	if codec := ga.CheckpointCodec(); codec != nil {
+		if _, ok := codec.(codecAdapter[T]); !ok {
+			return nil, &src.ConfigError{Field: "checkpoint codec", Value: codec, Reason: "must be set with generic.WithCheckpoint"}
+		}
+	}
+	return &GA[T]{ga: ga}, nil
+}
+
+func adaptModel[T Individual[T]](model Model[T]) src.Model {
+	if pairModel, ok := model.(PairModel[T]); ok {
+		return pairModelAdapter[T]{modelAdapter: modelAdapter[T]{typed: model}, pair: pairModel}
+	}
+	return modelAdapter[T]{typed: model}
+}
+
+// Run breeds up to the configured number of generations. See src.GA.Run.
+func (g *GA[T]) Run() T {
+	return unwrap[T](g.ga.Run())
+}
+
+// RunContext is like Run but stops early when ctx is done. See src.GA.RunContext.
+func (g *GA[T]) RunContext(ctx context.Context) (T, error) {
+	best, err := g.ga.RunContext(ctx)
+	return unwrap[T](best), err
+}
+
+// RunResult is src.RunResult with typed individuals. The embedded Best and FinalPopulation
+// fields are left empty; use the typed ones.
+type RunResult[T any] struct {
+	src.RunResult
+	Best            T
+	FinalPopulation []T
+}
+
+// RunWithResult runs like RunContext and describes the whole run. See src.GA.RunWithResult.
+func (g *GA[T]) RunWithResult(ctx context.Context) (RunResult[T], error) {
+	result, err := g.ga.RunWithResult(ctx)
+	typed := RunResult[T]{
+		Best:            unwrap[T](result.Best),
+		FinalPopulation: make([]T, len(result.FinalPopulation)),
+	}
+	for i, individual := range result.FinalPopulation {
+		typed.FinalPopulation[i] = unwrap[T](individual)
+	}
+	result.Best = nil
+	result.FinalPopulation = nil
+	typed.RunResult = result
+
+	return typed, err
+}
+
+func (g *GA[T]) TerminationReason() string {
+	return g.ga.TerminationReason()
+}
+
+func (g *GA[T]) Seed() int64 {
+	return g.ga.Seed()
+}
+
+func (g *GA[T]) OperatorErrors() src.OperatorErrorCounts {
+	return g.ga.OperatorErrors()
+}
+
+func (g *GA[T]) Generation() int {
+	return g.ga.Generation()
+}
+
+func (g *GA[T]) History() []src.GenerationStats {
+	return g.ga.History()
+}
+
+// Stop ends the current run after the generation that is being bred. See src.GA.Stop.
+func (g *GA[T]) Stop() {
+	g.ga.Stop()
+}
+
+// individualAdapter lets a typed individual travel through the src engine.
+type individualAdapter[T Individual[T]] struct {
+	value T
+}
+
+func (i individualAdapter[T]) CalculateFitness() float64 {
+	return i.value.CalculateFitness()
+}
+
+func (i individualAdapter[T]) GenerateIndividual(rng *rand.Rand) src.Individual {
+	return individualAdapter[T]{value: i.value.GenerateIndividual(rng)}
+}
+
+func (i individualAdapter[T]) Clone() src.Individual {
+	if cloner, ok := any(i.value).(interface{ Clone() T }); ok {
+		return individualAdapter[T]{value: cloner.Clone()}
+	}
+	return individualAdapter[T]{value: src.DeepCopy(i.value)}
+}
+
+// DiversityKey keys the typed value, so diversity compares genes and not adapters.
+func (i individualAdapter[T]) DiversityKey() string {
+	return src.DiversityKey(i.value)
+}
+
+// modelAdapter runs typed operators inside the src engine. Only individualAdapter values
+// ever reach it, so the assertions below cannot fail.
+type modelAdapter[T Individual[T]] struct {
+	src.DefaultModel
+	typed Model[T]
+}
+
+func (m modelAdapter[T]) Crossover(parent1 src.Individual, parent2 src.Individual, rng *rand.Rand) (src.Individual, error) {
+	child, err := m.typed.Crossover(unwrap[T](parent1), unwrap[T](parent2), rng)
+	if err != nil {
+		return nil, err
+	}
+	return individualAdapter[T]{value: child}, nil
+}
+
+func (m modelAdapter[T]) Mutate(individual src.Individual, rng *rand.Rand) (src.Individual, error) {
+	mutated, err := m.typed.Mutate(unwrap[T](individual), rng)
+	if err != nil {
+		return nil, err
+	}
+	return individualAdapter[T]{value: mutated}, nil
+}
+
+func (m modelAdapter[T]) SupportsGeneMutation() bool {
+	return src.SupportsGeneMutation[T](m.typed)
+}
+
+func (m modelAdapter[T]) MutateGenes(individual src.Individual, rate float64, rng *rand.Rand) (src.Individual, int, error) {
+	geneMutator, ok := m.typed.(src.GeneMutator[T])
+	if !ok {
+		return nil, 0, fmt.Errorf("%T does not support per-gene mutation", m.typed)
+	}
+	mutated, count, err := geneMutator.MutateGenes(unwrap[T](individual), rate, rng)
+	if err != nil {
+		return nil, 0, err
+	}
+	return individualAdapter[T]{value: mutated}, count, nil
+}
+
+// pairModelAdapter is the modelAdapter of a PairModel.
+type pairModelAdapter[T Individual[T]] struct {
+	modelAdapter[T]
+	pair PairModel[T]
+}
+
+func (m pairModelAdapter[T]) CrossoverPair(parent1 src.Individual, parent2 src.Individual, rng *rand.Rand) (src.Individual, src.Individual, error) {
+	child1, child2, err := m.pair.CrossoverPair(unwrap[T](parent1), unwrap[T](parent2), rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	return individualAdapter[T]{value: child1}, individualAdapter[T]{value: child2}, nil
+}
+
+func unwrap[T Individual[T]](individual src.Individual) T {
+	if individual == nil {
+		var zero T
+		return zero
+	}
+	return individual.(individualAdapter[T]).value
+}
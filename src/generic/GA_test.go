@@ -0,0 +1,110 @@
+package generic
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
+)
+
+// perm is a permutation whose fitness is the number of genes already in place.
+type perm []int
+
+func (p perm) CalculateFitness() float64 {
+	fitness := 0.0
+	for i, gene := range p {
+		if gene == i {
+			fitness++
+		}
+	}
+	return fitness
+}
+
+func (p perm) GenerateIndividual(rng *rand.Rand) perm {
+	return rng.Perm(20)
+}
+
+func testOptions(opts ...src.Option) []src.Option {
+	return append([]src.Option{
+		src.WithPopulationSize(100),
+		src.WithGenerations(12),
+		src.WithSeed(42),
+		src.WithElitismRate(0.1),
+	}, opts...)
+}
+
+// bestObserver records the typed best individuals it is told about.
+type bestObserver struct {
+	DefaultObserver[perm]
+	best []perm
+}
+
+func (bo *bestObserver) OnNewBest(generation int, best perm, fitness float64) {
+	bo.best = append(bo.best, best)
+}
+
+func TestRunWithResultReturnsTypedIndividuals(t *testing.T) {
+	observer := &bestObserver{}
+	ga, err := NewGA(perm{}, PermutationModel[perm, int]{}, testOptions(WithObserver[perm](observer))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ga.RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.RunResult.Best != nil || result.RunResult.FinalPopulation != nil {
+		t.Error("the embedded untyped Best and FinalPopulation are not empty")
+	}
+	if len(result.Best) != 20 || result.Best.CalculateFitness() != result.BestFitness {
+		t.Errorf("best %v does not have the recorded fitness %v", result.Best, result.BestFitness)
+	}
+	if len(result.FinalPopulation) != 100 {
+		t.Fatalf("final population has %d individuals, want 100", len(result.FinalPopulation))
+	}
+	for i, individual := range result.FinalPopulation {
+		if individual.CalculateFitness() != result.FinalFitness[i] {
+			t.Errorf("individual %d has fitness %v, but %v is recorded", i, individual.CalculateFitness(), result.FinalFitness[i])
+		}
+	}
+	if len(observer.best) == 0 || observer.best[len(observer.best)-1].CalculateFitness() != result.BestFitness {
+		t.Errorf("observer saw %d best individuals, the last of which is not the best of the run", len(observer.best))
+	}
+}
+
+// aliasingModel hands back the first parent as the child and mutates in place.
+type aliasingModel struct {
+	PermutationModel[perm, int]
+}
+
+func (aliasingModel) Crossover(parent1 perm, parent2 perm, rng *rand.Rand) (perm, error) {
+	return parent1, nil
+}
+
+func (aliasingModel) Mutate(individual perm, rng *rand.Rand) (perm, error) {
+	i, j := rng.Intn(len(individual)), rng.Intn(len(individual))
+	individual[i], individual[j] = individual[j], individual[i]
+	return individual, nil
+}
+
+func TestOffspringDoNotShareGenesWithParents(t *testing.T) {
+	ga, err := NewGA(perm{}, aliasingModel{}, testOptions(src.WithMutationRate(1))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ga.RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, individual := range result.FinalPopulation {
+		if individual.CalculateFitness() != result.FinalFitness[i] {
+			t.Errorf("individual %d has fitness %v, but %v is recorded", i, individual.CalculateFitness(), result.FinalFitness[i])
+		}
+	}
+	if result.Best.CalculateFitness() != result.BestFitness {
+		t.Errorf("best individual has fitness %v, but %v is recorded", result.Best.CalculateFitness(), result.BestFitness)
+	}
+}
@@ -0,0 +1,85 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
+)
+
+// Codec is the typed counterpart of src.Codec. No prototype is needed, T is the type to
+// decode into.
+type Codec[T any] interface {
+	Marshal(individual T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// JSONCodec encodes individuals as JSON.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Marshal(individual T) ([]byte, error) {
+	return json.Marshal(individual)
+}
+
+func (JSONCodec[T]) Unmarshal(data []byte) (T, error) {
+	var individual T
+	err := json.Unmarshal(data, &individual)
+	return individual, err
+}
+
+// GobCodec encodes individuals with encoding/gob.
+type GobCodec[T any] struct{}
+
+func (GobCodec[T]) Marshal(individual T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(individual); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var individual T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&individual)
+	return individual, err
+}
+
+// WithCheckpoint is src.WithCheckpoint for a GA of this package.
+func WithCheckpoint[T Individual[T]](path string, codec Codec[T], every int) src.Option {
+	return src.WithCheckpoint(path, codecAdapter[T]{typed: codec}, every)
+}
+
+// SaveCheckpoint writes the state of the GA to path. See src.GA.SaveCheckpoint.
+func (g *GA[T]) SaveCheckpoint(path string, codec Codec[T]) error {
+	return g.ga.SaveCheckpoint(path, codecAdapter[T]{typed: codec})
+}
+
+// ResumeGA rebuilds a GA from a checkpoint written with codec. Like NewGA, it takes the
+// model separately from the options. See src.ResumeGA.
+func ResumeGA[T Individual[T]](path string, codec Codec[T], model Model[T], opts ...src.Option) (*GA[T], error) {
+	opts = append(opts, src.WithModel(adaptModel(model)))
+	ga, err := src.ResumeGA(path, codecAdapter[T]{typed: codec}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGA[T](ga)
+}
+
+// codecAdapter encodes the typed values of individualAdapter.
+type codecAdapter[T Individual[T]] struct {
+	typed Codec[T]
+}
+
+func (c codecAdapter[T]) Marshal(individual src.Individual) ([]byte, error) {
+	return c.typed.Marshal(unwrap[T](individual))
+}
+
+func (c codecAdapter[T]) Unmarshal(data []byte) (src.Individual, error) {
+	individual, err := c.typed.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return individualAdapter[T]{value: individual}, nil
+}
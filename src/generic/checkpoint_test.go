@@ -0,0 +1,63 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
+)
+
+func TestResumedRunMatchesUninterruptedRun(t *testing.T) {
+	uninterrupted, err := NewGA(perm{}, PermutationModel[perm, int]{}, testOptions()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := uninterrupted.RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, codec := range []Codec[perm]{JSONCodec[perm]{}, GobCodec[perm]{}} {
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		// The run stops right after saving the checkpoint of generation 5.
+		interrupted, err := NewGA(perm{}, PermutationModel[perm, int]{},
+			testOptions(WithCheckpoint[perm](path, codec, 5), src.WithTermination(src.MaxGenerations(5)))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := interrupted.RunWithResult(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		resumed, err := ResumeGA[perm](path, codec, PermutationModel[perm, int]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := resumed.RunWithResult(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Generations != want.Generations {
+			t.Fatalf("%T: resumed run reached generation %d, want %d", codec, got.Generations, want.Generations)
+		}
+		if !reflect.DeepEqual(got.FinalPopulation, want.FinalPopulation) {
+			t.Errorf("%T: final population differs from the uninterrupted run", codec)
+		}
+		if !reflect.DeepEqual(got.Best, want.Best) || got.BestFitness != want.BestFitness {
+			t.Errorf("%T: best %v with fitness %v, want %v with fitness %v", codec, got.Best, got.BestFitness, want.Best, want.BestFitness)
+		}
+	}
+}
+
+func TestNewGARejectsUntypedCheckpointCodec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	_, err := NewGA(perm{}, PermutationModel[perm, int]{},
+		testOptions(src.WithCheckpoint(path, src.JSONCodec{}, 5))...)
+	var configErr *src.ConfigError
+	if !errors.As(err, &configErr) {
+		t.Errorf("NewGA with src.WithCheckpoint returned %v, want a ConfigError", err)
+	}
+}
@@ -0,0 +1,76 @@
+package generic
+
+import "github.com/hamza-aloglu/GeneticAlgo-Go/src"
+
+// Observer is the typed counterpart of src.Observer. Register it with WithObserver of this
+// package.
+type Observer[T Individual[T]] interface {
+	OnRunStart(ga *GA[T])
+	OnGenerationStart(generation int)
+	OnGenerationEnd(generation int, stats src.GenerationStats)
+	OnNewBest(generation int, best T, fitness float64)
+	OnStagnation(generation int, generationsWithoutImprovement int)
+	OnRunEnd(generation int, best T, reason string)
+}
+
+// DefaultObserver ignores every notification. Embed it to implement only the methods you need.
+type DefaultObserver[T Individual[T]] struct {
+}
+
+func (do DefaultObserver[T]) OnRunStart(ga *GA[T]) {}
+
+func (do DefaultObserver[T]) OnGenerationStart(generation int) {}
+
+func (do DefaultObserver[T]) OnGenerationEnd(generation int, stats src.GenerationStats) {}
+
+func (do DefaultObserver[T]) OnNewBest(generation int, best T, fitness float64) {}
+
+func (do DefaultObserver[T]) OnStagnation(generation int, generationsWithoutImprovement int) {}
+
+func (do DefaultObserver[T]) OnRunEnd(generation int, best T, reason string) {}
+
+// WithObserver is src.WithObserver for typed observers.
+func WithObserver[T Individual[T]](observers ...Observer[T]) src.Option {
+	adapters := make([]src.Observer, len(observers))
+	for i, observer := range observers {
+		adapters[i] = observerAdapter[T]{typed: observer}
+	}
+	return src.WithObserver(adapters...)
+}
+
+// WithGenerationHook is src.WithGenerationHook for a hook that takes the typed best
+// individual.
+func WithGenerationHook[T Individual[T]](hook func(individual T)) src.Option {
+	return src.WithGenerationHook(func(individual src.Individual) {
+		hook(unwrap[T](individual))
+	})
+}
+
+// observerAdapter unwraps the individuals the src engine reports.
+type observerAdapter[T Individual[T]] struct {
+	typed Observer[T]
+}
+
+func (o observerAdapter[T]) OnRunStart(ga *src.GA) {
+	o.typed.OnRunStart(&GA[T]{ga: ga})
+}
+
+func (o observerAdapter[T]) OnGenerationStart(generation int) {
+	o.typed.OnGenerationStart(generation)
+}
+
+func (o observerAdapter[T]) OnGenerationEnd(generation int, stats src.GenerationStats) {
+	o.typed.OnGenerationEnd(generation, stats)
+}
+
+func (o observerAdapter[T]) OnNewBest(generation int, best src.Individual, fitness float64) {
+	o.typed.OnNewBest(generation, unwrap[T](best), fitness)
+}
+
+func (o observerAdapter[T]) OnStagnation(generation int, generationsWithoutImprovement int) {
+	o.typed.OnStagnation(generation, generationsWithoutImprovement)
+}
+
+func (o observerAdapter[T]) OnRunEnd(generation int, best src.Individual, reason string) {
+	o.typed.OnRunEnd(generation, unwrap[T](best), reason)
+}
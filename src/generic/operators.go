@@ -0,0 +1,42 @@
+package generic
+
+import (
+	"math/rand"
+
+	"github.com/hamza-aloglu/GeneticAlgo-Go/src"
+)
+
+// OnePointCrossover copies genes before a random point from parent1 and the rest from
+// parent2. It does not ensure uniqueness of genes. More slice crossovers, such as
+// src.TwoPointCrossover and src.BlendCrossover, can be used with this package as they are.
+func OnePointCrossover[T ~[]G, G any](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return src.OnePointCrossover(parent1, parent2, rng)
+}
+
+// OrderCrossover is ordered crossover (OX). See src for PMX, CX and the other
+// permutation crossovers.
+func OrderCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return src.OrderCrossover(parent1, parent2, rng)
+}
+
+// SwapMutation swaps the genes at two different random positions. See src for
+// inversion, insertion, displacement and scramble mutations.
+func SwapMutation[T ~[]G, G any](individual T, rng *rand.Rand) (T, error) {
+	return src.SwapMutation[T]().Mutate(individual, rng)
+}
+
+// PermutationModel combines OrderCrossover and SwapMutation for slice individuals.
+type PermutationModel[T ~[]G, G comparable] struct{}
+
+func (PermutationModel[T, G]) Crossover(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return OrderCrossover(parent1, parent2, rng)
+}
+
+func (PermutationModel[T, G]) Mutate(individual T, rng *rand.Rand) (T, error) {
+	return SwapMutation(individual, rng)
+}
+
+// MutateGenes swaps every gene with probability rate with a gene at another random position.
+func (PermutationModel[T, G]) MutateGenes(individual T, rate float64, rng *rand.Rand) (T, int, error) {
+	return src.SwapMutation[T]().MutateGenes(individual, rate, rng)
+}
@@ -0,0 +1,158 @@
+package src
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Genome gives the built-in operators access to the genes of an individual, whatever its
+// shape: a slice type or a struct that keeps its genes in a field. Clone must copy the
+// genes, so that Set and Swap on the clone leave the original unchanged. Slice
+// individuals need not implement Genome; their genes are accessed with reflection.
+type Genome interface {
+	Cloner
+	Len() int
+	Get(i int) interface{}
+	Set(i int, gene interface{})
+	Swap(i int, j int)
+}
+
+// genomeOf returns individual as a Genome.
+func genomeOf(individual interface{}) (Genome, error) {
+	if genome, ok := individual.(Genome); ok {
+		return genome, nil
+	}
+	genes := reflect.ValueOf(individual)
+	if genes.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("individual is %T, which is neither a Genome nor a slice", individual)
+	}
+	return &sliceGenome{genes: genes}, nil
+}
+
+// cloneGenome returns a copy of individual and the Genome of the copy, which operators may
+// change.
+func cloneGenome(individual Individual) (Individual, Genome, error) {
+	genome, err := genomeOf(individual)
+	if err != nil {
+		return nil, nil, err
+	}
+	clone := genome.Clone()
+	cloneGenome, err := genomeOf(clone)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clone, cloneGenome, nil
+}
+
+// sliceGenome is the Genome of a slice individual.
+type sliceGenome struct {
+	genes reflect.Value
+	swap  func(i int, j int) // made on the first Swap
+}
+
+func (sg *sliceGenome) Len() int {
+	return sg.genes.Len()
+}
+
+func (sg *sliceGenome) Get(i int) interface{} {
+	return sg.genes.Index(i).Interface()
+}
+
+func (sg *sliceGenome) Set(i int, gene interface{}) {
+	sg.genes.Index(i).Set(reflect.ValueOf(gene))
+}
+
+func (sg *sliceGenome) Swap(i int, j int) {
+	if sg.swap == nil {
+		sg.swap = reflect.Swapper(sg.genes.Interface())
+	}
+	sg.swap(i, j)
+}
+
+func (sg *sliceGenome) Clone() Individual {
+	clone := reflect.MakeSlice(sg.genes.Type(), sg.genes.Len(), sg.genes.Len())
+	reflect.Copy(clone, sg.genes)
+	return clone.Interface().(Individual)
+}
+
+// copyGenome copies the genes in [from, to) of src to dst, in one block when both are
+// slices of the same type.
+func copyGenome(dst Genome, src Genome, from int, to int) {
+	dstSlice, ok1 := dst.(*sliceGenome)
+	srcSlice, ok2 := src.(*sliceGenome)
+	if ok1 && ok2 && dstSlice.genes.Type() == srcSlice.genes.Type() {
+		reflect.Copy(dstSlice.genes.Slice(from, to), srcSlice.genes.Slice(from, to))
+		return
+	}
+	for i := from; i < to; i++ {
+		dst.Set(i, src.Get(i))
+	}
+}
+
+// changedGenes counts the genes of after that differ from before. ok is false when they
+// cannot be compared: when they are not Genomes or when after is before, or shares its
+// genes, changed in place. See sharesGenes.
+func changedGenes(before Individual, after Individual) (int, bool) {
+	if sharesGenes(after, before) {
+		return 0, false
+	}
+	beforeGenes, err1 := genomeOf(before)
+	afterGenes, err2 := genomeOf(after)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+
+	n := min(beforeGenes.Len(), afterGenes.Len())
+	changed := max(beforeGenes.Len(), afterGenes.Len()) - n
+	beforeSlice, ok1 := beforeGenes.(*sliceGenome)
+	afterSlice, ok2 := afterGenes.(*sliceGenome)
+	if ok1 && ok2 && beforeSlice.genes.Type() == afterSlice.genes.Type() {
+		geneType := beforeSlice.genes.Type().Elem()
+		if geneType.Comparable() && geneType.Kind() != reflect.Interface {
+			for i := 0; i < n; i++ {
+				if !beforeSlice.genes.Index(i).Equal(afterSlice.genes.Index(i)) {
+					changed++
+				}
+			}
+			return changed, true
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !reflect.DeepEqual(beforeGenes.Get(i), afterGenes.Get(i)) {
+			changed++
+		}
+	}
+	return changed, true
+}
+
+// genesOf copies the genes of individual into a slice of type []G.
+func genesOf[G any](individual Individual) ([]G, error) {
+	genome, err := genomeOf(individual)
+	if err != nil {
+		return nil, err
+	}
+	genes := make([]G, genome.Len())
+	for i := range genes {
+		gene, ok := genome.Get(i).(G)
+		if !ok {
+			return nil, fmt.Errorf("gene %d is %T, not %T", i, genome.Get(i), *new(G))
+		}
+		genes[i] = gene
+	}
+	return genes, nil
+}
+
+// withGenes returns a copy of individual that holds genes.
+func withGenes[G any](individual Individual, genes []G) (Individual, error) {
+	clone, genome, err := cloneGenome(individual)
+	if err != nil {
+		return nil, err
+	}
+	if genome.Len() != len(genes) {
+		return nil, fmt.Errorf("operator returned %d genes for an individual with %d", len(genes), genome.Len())
+	}
+	for i, gene := range genes {
+		genome.Set(i, gene)
+	}
+	return clone, nil
+}
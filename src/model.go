@@ -6,66 +6,195 @@ import (
 	"reflect"
 )
 
+// Model holds the genetic operators. Every call gets the random source of the worker that
+// makes it; operators should draw from rng only, so that seeded runs can be repeated.
+// SelectParent is not used when a Selector is set with WithSelector. Crossover must not
+// change the parents, which stay in the population, and should return children that do
+// not share genes with them; slice children that do are copied, see Cloner. Mutate gets
+// an offspring nothing else refers to and may change it in place.
 type Model interface {
-	SelectParent(population *Population) Individual
-	Crossover(parent1 Individual, parent2 Individual) (Individual, error)
-	Mutate(individual Individual) (Individual, error)
+	SelectParent(population *Population, rng *rand.Rand) Individual
+	Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error)
+	Mutate(individual Individual, rng *rand.Rand) (Individual, error)
 }
 
-type DefaultModel struct {
+// GeneMutationModel is implemented by models that can mutate every gene of an individual
+// independently, which WithGeneMutationRate needs. MutateGenes returns the mutated
+// individual and how many of its genes mutated. Models that wrap other operators, and can
+// mutate per gene only when those can, also have a SupportsGeneMutation() bool method that
+// NewGA checks. A model that embeds one with MutateGenes, such as DefaultModel, must
+// confirm with SupportsGeneMutation that the promoted MutateGenes matches its Mutate.
+type GeneMutationModel interface {
+	MutateGenes(individual Individual, rate float64, rng *rand.Rand) (Individual, int, error)
+}
+
+type geneMutationSupport interface {
+	SupportsGeneMutation() bool
+}
+
+// supportsGeneMutation reports whether operator, a Model or a Mutator, can mutate per gene.
+// isGeneMutator tells whether it has a MutateGenes method at all.
+func supportsGeneMutation(operator interface{}, isGeneMutator bool) bool {
+	if support, ok := operator.(geneMutationSupport); ok {
+		return support.SupportsGeneMutation()
+	}
+	switch operator.(type) {
+	case DefaultModel, *DefaultModel, PermutationModel, *PermutationModel:
+		// PermutationModel declares both of its mutations, next to the embedded ones.
+		return true
+	}
+	return isGeneMutator && !embedsGeneMutation(reflect.TypeOf(operator))
 }
 
-func (dm DefaultModel) SelectParent(population *Population) Individual {
-	individuals := population.individuals
-	totalFitnessScore := population.getTotalFitnessScore()
-	fitnessThreshold := rand.Intn(int(totalFitnessScore))
-	currentFitness := 0.0
-	for _, individual := range individuals {
-		currentFitness += individual.CalculateFitness()
-		if currentFitness >= float64(fitnessThreshold) {
-			return individual
+// embedsGeneMutation reports whether t is a struct, or a pointer to one, with an embedded
+// field that has a MutateGenes method. That method may be promoted and then bypass a
+// Mutate the struct declares itself, so it is not trusted without SupportsGeneMutation.
+func embedsGeneMutation(t reflect.Type) bool {
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		if _, ok := reflect.PointerTo(field.Type).MethodByName("MutateGenes"); ok {
+			return true
 		}
 	}
+	return false
+}
 
-	return individuals[0]
+// PairCrossoverModel is implemented by models whose crossover produces two children. The
+// engine then keeps both, so every crossover fills two places of the next generation.
+type PairCrossoverModel interface {
+	CrossoverPair(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, Individual, error)
 }
 
-// Crossover is fixed point crossover. It does not ensure uniqueness of genes.
-func (dm DefaultModel) Crossover(parent1 Individual, parent2 Individual) (Individual, error) {
-	p1 := reflect.ValueOf(parent1)
-	p2 := reflect.ValueOf(parent2)
-	child := reflect.MakeSlice(p1.Type(), p1.Len(), p1.Len())
+// DefaultModel selects parents with roulette, recombines slices with one-point crossover
+// and mutates individuals that implement AlleleProvider. Its zero value uses RandomReset.
+// For per-gene reset or creep, use WithGeneMutationRate.
+type DefaultModel struct {
+	AlleleMutation AlleleMutation
+	// CreepStep is the largest number of allele positions CreepMutation moves a gene; 0 means 1.
+	CreepStep int
+}
+
+// SelectParent is fitness proportionate (roulette wheel) selection on the weights of the
+// population's FitnessScaling. It works with any real valued fitness in both objective
+// directions, and binary searches the cumulative weights built once per generation.
+func (dm DefaultModel) SelectParent(population *Population, rng *rand.Rand) Individual {
+	threshold := rng.Float64() * population.totalWeight
+	return population.individuals[population.searchWeight(threshold)]
+}
 
-	if p1.Kind() != reflect.Slice || p2.Kind() != reflect.Slice {
-		return nil, errors.New("parent(s) are not slice")
+// Crossover is fixed point crossover. It does not ensure uniqueness of genes. It works on
+// any Genome, see genomeOf.
+func (dm DefaultModel) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	p2, err := genomeOf(parent2)
+	if err != nil {
+		return nil, err
 	}
-	if p1.Len() != p2.Len() {
-		return nil, errors.New("both slices must have the same length")
+	child, childGenes, err := cloneGenome(parent1)
+	if err != nil {
+		return nil, err
+	}
+	if childGenes.Len() != p2.Len() {
+		return nil, errors.New("both parents must have the same number of genes")
 	}
 
-	crossoverPoint := rand.Intn(p1.Len())
-	for i := 0; i < p1.Len(); i++ {
-		if i < crossoverPoint {
-			child.Index(i).Set(p1.Index(i))
-		} else {
-			child.Index(i).Set(p2.Index(i))
-		}
+	if p2.Len() == 0 {
+		return child, nil
 	}
 
-	return child.Interface().(Individual), nil
+	crossoverPoint := rng.Intn(p2.Len())
+	copyGenome(childGenes, p2, crossoverPoint, p2.Len())
+
+	return child, nil
 }
 
-func (dm DefaultModel) Mutate(individual Individual) (Individual, error) {
-	/*allelesReflection := reflect.ValueOf(individual.GetAlleles())
-	if allelesReflection.Kind() != reflect.Slice {
-		return nil, errors.New("Allele must be type of slice")
+// Mutate changes a copy of an individual that implements AlleleProvider, the way
+// AlleleMutation tells. It works on any Genome, see genomeOf.
+func (dm DefaultModel) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	provider, mutated, genes, err := cloneAlleleGenome(individual)
+	if err != nil {
+		return nil, err
+	}
+	n := genes.Len()
+	if n == 0 {
+		return mutated, nil
 	}
-	individualReflection := reflect.ValueOf(individual)
 
-	individualReflection.
-		Index(rand.Intn(individualReflection.Len())).
-		Set(allelesReflection.Index(rand.Intn(allelesReflection.Len())))
+	if err := dm.mutateLocus(provider, genes, rng.Intn(n), rng); err != nil {
+		return nil, err
+	}
 
-	return individualReflection.Interface().(Individual), nil*/
-	panic("Mutate is not implemented in DefaultModel!")
+	return mutated, nil
+}
+
+// MutateGenes changes every gene of a copy of individual with probability rate, by random
+// reset or, with CreepMutation, by creep.
+func (dm DefaultModel) MutateGenes(individual Individual, rate float64, rng *rand.Rand) (Individual, int, error) {
+	provider, mutated, genes, err := cloneAlleleGenome(individual)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := dm.mutateGenes(provider, genes, rate, rng)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mutated, count, nil
+}
+
+func cloneAlleleGenome(individual Individual) (AlleleProvider, Individual, Genome, error) {
+	provider, ok := individual.(AlleleProvider)
+	if !ok {
+		return nil, nil, nil, errors.New("DefaultModel.Mutate needs an individual that implements AlleleProvider")
+	}
+	mutated, genes, err := cloneGenome(individual)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return provider, mutated, genes, nil
+}
+
+func (dm DefaultModel) mutateGenes(provider AlleleProvider, genes Genome, rate float64, rng *rand.Rand) (int, error) {
+	count := 0
+	for locus := 0; locus < genes.Len(); locus++ {
+		if rng.Float64() < rate {
+			if err := dm.mutateLocus(provider, genes, locus, rng); err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mutateLocus creeps the gene at locus with CreepMutation and resets it otherwise.
+func (dm DefaultModel) mutateLocus(provider AlleleProvider, genes Genome, locus int, rng *rand.Rand) error {
+	alleles, err := allelesAt(provider, locus)
+	if err != nil {
+		return err
+	}
+	if dm.AlleleMutation != CreepMutation {
+		genes.Set(locus, alleles.Index(rng.Intn(alleles.Len())).Interface())
+		return nil
+	}
+
+	current := indexOfAllele(alleles, genes.Get(locus))
+	if current < 0 {
+		return errors.New("gene is not one of its alleles")
+	}
+	step := max(dm.CreepStep, 1)
+	offset := 1 + rng.Intn(step)
+	if rng.Intn(2) == 0 {
+		offset = -offset
+	}
+	next := min(max(current+offset, 0), alleles.Len()-1)
+	genes.Set(locus, alleles.Index(next).Interface())
+	return nil
 }
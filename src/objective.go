@@ -0,0 +1,42 @@
+package src
+
+import "fmt"
+
+// Objective tells whether higher or lower fitness is better. Fitness can be any real
+// number in both directions, including zero and negative values.
+type Objective int
+
+const (
+	Maximize Objective = iota
+	Minimize
+)
+
+// better reports whether fitness a is strictly better than fitness b.
+func (o Objective) better(a float64, b float64) bool {
+	if o == Minimize {
+		return a < b
+	}
+	return a > b
+}
+
+func (o Objective) String() string {
+	switch o {
+	case Maximize:
+		return "maximize"
+	case Minimize:
+		return "minimize"
+	default:
+		return fmt.Sprintf("Objective(%d)", int(o))
+	}
+}
+
+// scores turns fitness into scores where higher is always better.
+func (o Objective) scores(fitness []float64, scores []float64) {
+	for i, f := range fitness {
+		if o == Minimize {
+			scores[i] = -f
+		} else {
+			scores[i] = f
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package src
+
+// Observer is notified about the progress of a run. Several observers can be registered
+// with WithObserver; they are called in registration order from the goroutine that runs
+// the GA. An observer can end the run early by calling GA.Stop.
+type Observer interface {
+	// OnRunStart is called before the first generation is bred.
+	OnRunStart(ga *GA)
+	// OnGenerationStart is called before the given generation is bred.
+	OnGenerationStart(generation int)
+	// OnGenerationEnd is called with the statistics of every bred generation.
+	OnGenerationEnd(generation int, stats GenerationStats)
+	// OnNewBest is called when a generation improves on the best fitness so far.
+	OnNewBest(generation int, best Individual, fitness float64)
+	// OnStagnation is called when a generation does not improve on the best fitness.
+	OnStagnation(generation int, generationsWithoutImprovement int)
+	// OnRunEnd is called once the run stops, with the reason it stopped.
+	OnRunEnd(generation int, best Individual, reason string)
+}
+
+// DefaultObserver ignores every notification. Embed it to implement only the methods you need.
+type DefaultObserver struct {
+}
+
+func (do DefaultObserver) OnRunStart(ga *GA) {}
+
+func (do DefaultObserver) OnGenerationStart(generation int) {}
+
+func (do DefaultObserver) OnGenerationEnd(generation int, stats GenerationStats) {}
+
+func (do DefaultObserver) OnNewBest(generation int, best Individual, fitness float64) {}
+
+func (do DefaultObserver) OnStagnation(generation int, generationsWithoutImprovement int) {}
+
+func (do DefaultObserver) OnRunEnd(generation int, best Individual, reason string) {}
@@ -0,0 +1,311 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+const (
+	defaultGenerationNumber = 100
+	defaultPopulationSize   = 100
+	defaultMutationRate     = 0.1
+	defaultCrossoverRate    = 1
+	defaultElitismRate      = 0.01
+)
+
+// ConfigError reports a GA parameter that NewGA refused.
+type ConfigError struct {
+	Field  string
+	Value  interface{}
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid %s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+type config struct {
+	generationNumber int
+	populationSize   int
+	crossoverRate    float64
+	mutationRate     float64
+	geneMutationRate float64
+	elitismRate      float64
+	model            Model
+	seed             *int64
+	concurrency      int
+	termination      TerminationCriterion
+	generationHooks  []printIndividual
+	observers        []Observer
+	diversityStats   bool
+	checkpoint       *checkpointConfig
+	errorPolicy      ErrorPolicy
+	objective        Objective
+	scaling          FitnessScaling
+	selector         Selector
+}
+
+// Option configures a GA built by NewGA.
+type Option func(c *config)
+
+// WithGenerations sets the maximum number of generations of a run.
+func WithGenerations(n int) Option {
+	return func(c *config) { c.generationNumber = n }
+}
+
+// WithPopulationSize sets the number of individuals in every generation.
+func WithPopulationSize(n int) Option {
+	return func(c *config) { c.populationSize = n }
+}
+
+// WithCrossoverRate sets the probability that selected parents are recombined. Otherwise
+// the offspring is a copy of the first parent, or of both parents for a
+// PairCrossoverModel. The default is 1.
+func WithCrossoverRate(rate float64) Option {
+	return func(c *config) { c.crossoverRate = rate }
+}
+
+// WithMutationRate sets the probability that an offspring is mutated.
+func WithMutationRate(rate float64) Option {
+	return func(c *config) { c.mutationRate = rate }
+}
+
+// WithGeneMutationRate switches to per-gene mutation: every gene of every offspring
+// mutates independently with probability rate, and the rate of WithMutationRate is not
+// used. The model must implement GeneMutationModel. A rate of 0 keeps per-individual
+// mutation.
+func WithGeneMutationRate(rate float64) Option {
+	return func(c *config) { c.geneMutationRate = rate }
+}
+
+// WithElitismRate sets the share of best individuals copied unchanged into the next generation.
+func WithElitismRate(rate float64) Option {
+	return func(c *config) { c.elitismRate = rate }
+}
+
+// WithObjective sets whether fitness is maximized, the default, or minimized.
+func WithObjective(objective Objective) Option {
+	return func(c *config) { c.objective = objective }
+}
+
+// WithScaling sets how fitness is scaled before fitness proportionate selection.
+func WithScaling(scaling FitnessScaling) Option {
+	return func(c *config) { c.scaling = scaling }
+}
+
+// WithSelector sets the parent selection operator, replacing the SelectParent method of
+// the model.
+func WithSelector(selector Selector) Option {
+	return func(c *config) { c.selector = selector }
+}
+
+// WithModel sets the selection, crossover and mutation operators.
+func WithModel(model Model) Option {
+	return func(c *config) { c.model = model }
+}
+
+// WithSeed seeds the random sources so that runs can be repeated. Runs with the same seed
+// give the same result whatever the concurrency.
+func WithSeed(seed int64) Option {
+	return func(c *config) { c.seed = &seed }
+}
+
+// WithConcurrency sets the number of workers that breed and evaluate offspring. The default
+// is GOMAXPROCS; 1 runs every generation sequentially in the calling goroutine.
+func WithConcurrency(n int) Option {
+	return func(c *config) { c.concurrency = n }
+}
+
+// WithTermination sets a criterion that can end a run before the last generation.
+func WithTermination(criterion TerminationCriterion) Option {
+	return func(c *config) { c.termination = criterion }
+}
+
+// WithGenerationHook registers a callback that receives the best individual after every generation.
+func WithGenerationHook(hook func(individual Individual)) Option {
+	return func(c *config) { c.generationHooks = append(c.generationHooks, hook) }
+}
+
+// WithObserver registers observers that are notified about the progress of every run.
+func WithObserver(observers ...Observer) Option {
+	return func(c *config) { c.observers = append(c.observers, observers...) }
+}
+
+// WithDiversityStats measures the diversity of every generation for GenerationStats. It is
+// measured anyway when a MinDiversity criterion or an observer is set.
+func WithDiversityStats() Option {
+	return func(c *config) { c.diversityStats = true }
+}
+
+// WithCheckpoint saves the run to path with codec every n generations, and when the
+// process receives SIGINT. A run interrupted that way returns ErrInterrupted.
+func WithCheckpoint(path string, codec Codec, every int) Option {
+	return func(c *config) { c.checkpoint = &checkpointConfig{path: path, codec: codec, every: every} }
+}
+
+// WithErrorPolicy sets how operator errors are handled. The default is AbortOnError.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(c *config) { c.errorPolicy = policy }
+}
+
+func (c *config) validate() error {
+	var errs []error
+	if c.generationNumber < 1 {
+		errs = append(errs, &ConfigError{"generation number", c.generationNumber, "must be at least 1"})
+	}
+	if c.populationSize < 1 {
+		errs = append(errs, &ConfigError{"population size", c.populationSize, "must be at least 1"})
+	}
+	if !(c.crossoverRate >= 0 && c.crossoverRate <= 1) {
+		errs = append(errs, &ConfigError{"crossover rate", c.crossoverRate, "must be between 0 and 1"})
+	}
+	if !(c.mutationRate >= 0 && c.mutationRate <= 1) {
+		errs = append(errs, &ConfigError{"mutation rate", c.mutationRate, "must be between 0 and 1"})
+	}
+	if !(c.geneMutationRate >= 0 && c.geneMutationRate <= 1) {
+		errs = append(errs, &ConfigError{"gene mutation rate", c.geneMutationRate, "must be between 0 and 1"})
+	}
+	if _, ok := c.model.(GeneMutationModel); c.geneMutationRate > 0 && c.model != nil && !supportsGeneMutation(c.model, ok) {
+		reason := "does not support per-gene mutation"
+		if ok && embedsGeneMutation(reflect.TypeOf(c.model)) {
+			reason = "has an embedded MutateGenes that SupportsGeneMutation does not confirm"
+		}
+		errs = append(errs, &ConfigError{"model", c.model, reason})
+	}
+	if !(c.elitismRate >= 0 && c.elitismRate <= 1) {
+		errs = append(errs, &ConfigError{"elitism rate", c.elitismRate, "must be between 0 and 1"})
+	}
+	if c.objective != Maximize && c.objective != Minimize {
+		errs = append(errs, &ConfigError{"objective", c.objective, "is unknown"})
+	}
+	switch scaling := c.scaling.(type) {
+	case LinearScaling:
+		if !(scaling.Multiplier > 1) {
+			errs = append(errs, &ConfigError{"linear scaling multiplier", scaling.Multiplier, "must be greater than 1"})
+		}
+	case PowerLawScaling:
+		if !(scaling.K > 0) {
+			errs = append(errs, &ConfigError{"power law exponent", scaling.K, "must be positive"})
+		}
+	case RankScaling:
+		if !(scaling.Pressure >= 1 && scaling.Pressure <= 2) {
+			errs = append(errs, &ConfigError{"rank selection pressure", scaling.Pressure, "must be between 1 and 2"})
+		}
+	case *WindowScaling:
+		if scaling.Generations < 1 {
+			errs = append(errs, &ConfigError{"scaling window", scaling.Generations, "must be at least 1"})
+		}
+	}
+	switch selector := c.selector.(type) {
+	case TournamentSelection:
+		if selector.Size < 1 {
+			errs = append(errs, &ConfigError{"tournament size", selector.Size, "must be at least 1"})
+		}
+		if !(selector.Probability > 0 && selector.Probability <= 1) {
+			errs = append(errs, &ConfigError{"tournament probability", selector.Probability, "must be greater than 0 and at most 1"})
+		}
+	case LinearRankingSelection:
+		if !(selector.Pressure >= 1 && selector.Pressure <= 2) {
+			errs = append(errs, &ConfigError{"ranking selection pressure", selector.Pressure, "must be between 1 and 2"})
+		}
+	case ExponentialRankingSelection:
+		if !(selector.Base > 0 && selector.Base < 1) {
+			errs = append(errs, &ConfigError{"exponential ranking base", selector.Base, "must be between 0 and 1"})
+		}
+	case TruncationSelection:
+		if !(selector.Proportion > 0 && selector.Proportion <= 1) {
+			errs = append(errs, &ConfigError{"truncation proportion", selector.Proportion, "must be greater than 0 and at most 1"})
+		}
+	case BoltzmannSelection:
+		if !(selector.Temperature > 0) {
+			errs = append(errs, &ConfigError{"boltzmann temperature", selector.Temperature, "must be positive"})
+		}
+		if !(selector.Cooling >= 0 && selector.Cooling <= 1) {
+			errs = append(errs, &ConfigError{"boltzmann cooling", selector.Cooling, "must be between 0 and 1"})
+		}
+	}
+	if c.model == nil {
+		errs = append(errs, &ConfigError{"model", c.model, "must not be nil"})
+	}
+	if c.errorPolicy < AbortOnError || c.errorPolicy > FallbackOnError {
+		errs = append(errs, &ConfigError{"error policy", c.errorPolicy, "is unknown"})
+	}
+	if c.checkpoint != nil && c.checkpoint.codec == nil {
+		errs = append(errs, &ConfigError{"checkpoint codec", c.checkpoint.codec, "must not be nil"})
+	}
+	if c.checkpoint != nil && c.checkpoint.every < 1 {
+		errs = append(errs, &ConfigError{"checkpoint interval", c.checkpoint.every, "must be at least 1"})
+	}
+	if c.concurrency < 1 {
+		errs = append(errs, &ConfigError{"concurrency", c.concurrency, "must be at least 1"})
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewGA builds a GA that breeds individuals like the given one. Every parameter has a
+// default that can be overridden with options; parameters out of range are reported as
+// *ConfigError values.
+func NewGA(individual Individual, opts ...Option) (*GA, error) {
+	if individual == nil {
+		return nil, &ConfigError{"individual", individual, "must not be nil"}
+	}
+
+	c := newConfig(opts)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	seed := newSeed()
+	if c.seed != nil {
+		seed = *c.seed
+	}
+
+	return c.newGA(seed, generateInitialIndividuals(individual.GenerateIndividual, c.populationSize, seed)), nil
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		generationNumber: defaultGenerationNumber,
+		populationSize:   defaultPopulationSize,
+		crossoverRate:    defaultCrossoverRate,
+		mutationRate:     defaultMutationRate,
+		elitismRate:      defaultElitismRate,
+		model:            PermutationModel{},
+		concurrency:      runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+func (c *config) newGA(seed int64, individuals []Individual) *GA {
+	return &GA{
+		generationNumber: c.generationNumber,
+		populationSize:   c.populationSize,
+		termination:      c.termination,
+		generationHooks:  c.generationHooks,
+		observers:        c.observers,
+		diversityStats:   c.diversityStats,
+		checkpoint:       c.checkpoint,
+		population: Population{
+			crossoverRate:    c.crossoverRate,
+			mutationRate:     c.mutationRate,
+			geneMutationRate: c.geneMutationRate,
+			individuals:      individuals,
+			model:            c.model,
+			popSize:          c.populationSize,
+			elitismRate:      c.elitismRate,
+			concurrency:      c.concurrency,
+			errorPolicy:      c.errorPolicy,
+			seed:             seed,
+			objective:        c.objective,
+			scaling:          c.scaling,
+			selector:         c.selector,
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package src
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// swapModel declares its own Mutate next to the MutateGenes it embeds.
+type swapModel struct {
+	DefaultModel
+}
+
+func (swapModel) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	return SwapMutation[tour]().Mutate(individual.(tour), rng)
+}
+
+// confirmedSwapModel confirms that the embedded MutateGenes may be used.
+type confirmedSwapModel struct {
+	PermutationModel
+}
+
+func (confirmedSwapModel) SupportsGeneMutation() bool {
+	return true
+}
+
+func TestGeneMutationRateRejectsPromotedMutateGenes(t *testing.T) {
+	var configErr *ConfigError
+	if _, err := NewGA(tour{}, WithModel(swapModel{}), WithGeneMutationRate(0.1)); !errors.As(err, &configErr) {
+		t.Errorf("NewGA with an embedded MutateGenes returned %v, want a ConfigError", err)
+	}
+	if _, err := NewGA(tour{}, WithModel(swapModel{}), WithMutationRate(0.1)); err != nil {
+		t.Errorf("NewGA without a gene mutation rate returned %v", err)
+	}
+	for _, model := range []Model{DefaultModel{}, PermutationModel{}, confirmedSwapModel{}} {
+		if _, err := NewGA(tour{}, WithModel(model), WithGeneMutationRate(0.1)); err != nil {
+			t.Errorf("NewGA with %T returned %v", model, err)
+		}
+	}
+}
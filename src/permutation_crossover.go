@@ -0,0 +1,335 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var (
+	errNotPermutations = errors.New("parents are not permutations of each other")
+	errRepeatedGenes   = errors.New("parents repeat a gene")
+	errInvalidChild    = errors.New("child is not a permutation of its parents")
+)
+
+// permutationCrossover wraps a crossover that assumes both parents are permutations of
+// the same distinct genes. Parents and child are checked with hash-based gene counts.
+func permutationCrossover[T ~[]G, G comparable](crossover func(parent1 T, parent2 T, rng *rand.Rand) T) CrossoverOperator[T] {
+	return func(parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+		if len(parent1) != len(parent2) {
+			return nil, errLengthMismatch
+		}
+		if !samePermutation(parent1, parent2) {
+			return nil, errNotPermutations
+		}
+		if len(positions(parent1)) != len(parent1) {
+			return nil, errRepeatedGenes
+		}
+
+		child := crossover(parent1, parent2, rng)
+		if !samePermutation(parent1, child) {
+			return nil, errInvalidChild
+		}
+		return child, nil
+	}
+}
+
+// samePermutation reports whether a and b hold the same genes the same number of times.
+func samePermutation[T ~[]G, G comparable](a T, b T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[G]int, len(a))
+	for _, gene := range a {
+		counts[gene]++
+	}
+	for _, gene := range b {
+		counts[gene]--
+		if counts[gene] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// positions maps every gene to its index.
+func positions[T ~[]G, G comparable](permutation T) map[G]int {
+	index := make(map[G]int, len(permutation))
+	for i, gene := range permutation {
+		index[gene] = i
+	}
+	return index
+}
+
+func randomSegment(n int, rng *rand.Rand) (int, int) {
+	bound1, bound2 := rng.Intn(n+1), rng.Intn(n+1)
+	if bound1 > bound2 {
+		bound1, bound2 = bound2, bound1
+	}
+	return bound1, bound2
+}
+
+// OrderCrossover (OX) copies a random segment of parent1 and fills the remaining
+// positions, starting after the segment, with the missing genes in parent2's order.
+func OrderCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		n := len(parent1)
+		child := make(T, n)
+		if n == 0 {
+			return child
+		}
+
+		bound1, bound2 := randomSegment(n, rng)
+		taken := make(map[G]bool, n)
+		for i := bound1; i < bound2; i++ {
+			child[i] = parent1[i]
+			taken[parent1[i]] = true
+		}
+
+		childIndex := bound2
+		for count := 0; count < n; count++ {
+			gene := parent2[(bound2+count)%n]
+			if !taken[gene] {
+				child[childIndex%n] = gene
+				taken[gene] = true
+				childIndex++
+			}
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// PartiallyMappedCrossover (PMX) copies a random segment of parent1 and places the genes
+// of parent2's segment that are missing by following the mapping between the segments.
+// All other positions keep parent2's genes.
+func PartiallyMappedCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		n := len(parent1)
+		child := make(T, n)
+		filled := make([]bool, n)
+		bound1, bound2 := randomSegment(n, rng)
+
+		inSegment := make(map[G]bool, bound2-bound1)
+		for i := bound1; i < bound2; i++ {
+			child[i] = parent1[i]
+			filled[i] = true
+			inSegment[parent1[i]] = true
+		}
+
+		indexInParent2 := positions(parent2)
+		for i := bound1; i < bound2; i++ {
+			gene := parent2[i]
+			if inSegment[gene] {
+				continue
+			}
+			position := i
+			for position >= bound1 && position < bound2 {
+				position = indexInParent2[parent1[position]]
+			}
+			child[position] = gene
+			filled[position] = true
+		}
+
+		for i := range child {
+			if !filled[i] {
+				child[i] = parent2[i]
+			}
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// CycleCrossover (CX) splits the positions into the cycles formed by the two parents and
+// takes the cycles alternately from parent1 and parent2, so every gene keeps the position
+// it has in one of the parents.
+func CycleCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		n := len(parent1)
+		child := make(T, n)
+		visited := make([]bool, n)
+		indexInParent1 := positions(parent1)
+
+		cycle := 0
+		for start := 0; start < n; start++ {
+			if visited[start] {
+				continue
+			}
+			for i := start; !visited[i]; i = indexInParent1[parent2[i]] {
+				visited[i] = true
+				if cycle%2 == 0 {
+					child[i] = parent1[i]
+				} else {
+					child[i] = parent2[i]
+				}
+			}
+			cycle++
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// EdgeRecombinationCrossover (ERX) builds the child from the edges of both parents, read
+// as cycles. From every gene it moves to the neighbour with the fewest remaining
+// neighbours, and to a random unused gene when there is none.
+func EdgeRecombinationCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		n := len(parent1)
+		child := make(T, 0, n)
+		if n == 0 {
+			return child
+		}
+
+		neighbours := make(map[G]map[G]bool, n)
+		for _, parent := range []T{parent1, parent2} {
+			for i, gene := range parent {
+				if neighbours[gene] == nil {
+					neighbours[gene] = make(map[G]bool, 4)
+				}
+				for _, neighbour := range []G{parent[(i+n-1)%n], parent[(i+1)%n]} {
+					if neighbour != gene {
+						neighbours[gene][neighbour] = true
+					}
+				}
+			}
+		}
+
+		remaining := make([]G, n)
+		copy(remaining, parent1)
+		current := parent1[0]
+		if rng.Intn(2) == 1 {
+			current = parent2[0]
+		}
+		for {
+			child = append(child, current)
+			for i, gene := range remaining {
+				if gene == current {
+					remaining = append(remaining[:i], remaining[i+1:]...)
+					break
+				}
+			}
+			if len(remaining) == 0 {
+				return child
+			}
+
+			var candidates []G
+			fewest := -1
+			for _, gene := range remaining {
+				if !neighbours[current][gene] {
+					continue
+				}
+				delete(neighbours[gene], current)
+				switch count := len(neighbours[gene]); {
+				case fewest < 0 || count < fewest:
+					fewest = count
+					candidates = []G{gene}
+				case count == fewest:
+					candidates = append(candidates, gene)
+				}
+			}
+			if len(candidates) == 0 {
+				current = remaining[rng.Intn(len(remaining))]
+			} else {
+				current = candidates[rng.Intn(len(candidates))]
+			}
+		}
+	})(parent1, parent2, rng)
+}
+
+// PositionBasedCrossover (PBX) keeps parent1's genes at random positions and fills the
+// other positions with the missing genes in parent2's order.
+func PositionBasedCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		n := len(parent1)
+		child := make(T, n)
+		kept := make([]bool, n)
+		taken := make(map[G]bool, n)
+		for i := range parent1 {
+			if rng.Intn(2) == 0 {
+				child[i] = parent1[i]
+				kept[i] = true
+				taken[parent1[i]] = true
+			}
+		}
+
+		next := 0
+		for _, gene := range parent2 {
+			if taken[gene] {
+				continue
+			}
+			for kept[next] {
+				next++
+			}
+			child[next] = gene
+			next++
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// OrderBasedCrossover (OBX) picks genes at random positions of parent2 and reorders the
+// same genes in a copy of parent1 so that they appear in parent2's order.
+func OrderBasedCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		child := make(T, len(parent1))
+		copy(child, parent1)
+
+		var chosen []G
+		selected := make(map[G]bool)
+		for _, gene := range parent2 {
+			if rng.Intn(2) == 0 {
+				chosen = append(chosen, gene)
+				selected[gene] = true
+			}
+		}
+
+		next := 0
+		for i, gene := range child {
+			if selected[gene] {
+				child[i] = chosen[next]
+				next++
+			}
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// AlternatingPositionCrossover (AP) takes genes alternately from parent1 and parent2,
+// position by position, skipping genes the child already has.
+func AlternatingPositionCrossover[T ~[]G, G comparable](parent1 T, parent2 T, rng *rand.Rand) (T, error) {
+	return permutationCrossover(func(parent1 T, parent2 T, rng *rand.Rand) T {
+		child := make(T, 0, len(parent1))
+		taken := make(map[G]bool, len(parent1))
+		for i := range parent1 {
+			for _, gene := range []G{parent1[i], parent2[i]} {
+				if !taken[gene] {
+					child = append(child, gene)
+					taken[gene] = true
+				}
+			}
+		}
+		return child
+	})(parent1, parent2, rng)
+}
+
+// PermutationCrossoverByName returns a permutation crossover: "ox", "pmx", "cx", "erx",
+// "pbx", "obx" or "ap".
+func PermutationCrossoverByName[T ~[]G, G comparable](name string) (CrossoverOperator[T], error) {
+	switch name {
+	case "ox":
+		return OrderCrossover[T], nil
+	case "pmx":
+		return PartiallyMappedCrossover[T], nil
+	case "cx":
+		return CycleCrossover[T], nil
+	case "erx":
+		return EdgeRecombinationCrossover[T], nil
+	case "pbx":
+		return PositionBasedCrossover[T], nil
+	case "obx":
+		return OrderBasedCrossover[T], nil
+	case "ap":
+		return AlternatingPositionCrossover[T], nil
+	default:
+		return nil, fmt.Errorf("unknown permutation crossover %q", name)
+	}
+}
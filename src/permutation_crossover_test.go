@@ -0,0 +1,71 @@
+package src
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var permutationCrossoverNames = []string{"ox", "pmx", "cx", "erx", "pbx", "obx", "ap"}
+
+func TestPermutationCrossoversReturnPermutations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, name := range permutationCrossoverNames {
+		crossover, err := PermutationCrossoverByName[tour](name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range []int{0, 1, 2, 3, 10, 31} {
+			for trial := 0; trial < 200; trial++ {
+				parent1, parent2 := tour(rng.Perm(n)), tour(rng.Perm(n))
+				before1, before2 := append(tour{}, parent1...), append(tour{}, parent2...)
+
+				child, err := crossover(parent1, parent2, rng)
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if !isPermutation(child, n) {
+					t.Fatalf("%s of %v and %v returned %v, which is not a permutation", name, parent1, parent2, child)
+				}
+				if !reflect.DeepEqual(parent1, before1) || !reflect.DeepEqual(parent2, before2) {
+					t.Fatalf("%s changed its parents", name)
+				}
+			}
+		}
+
+		// Repeated genes are rejected rather than crossed.
+		for _, parents := range [][2]tour{{{0, 2, 2}, {0, 2, 2}}, {{1, 2, 0, 2, 2}, {0, 2, 2, 1, 2}}} {
+			if child, err := crossover(parents[0], parents[1], rng); err == nil {
+				t.Errorf("%s of %v and %v returned %v, want an error", name, parents[0], parents[1], child)
+			}
+		}
+	}
+}
+
+func TestPermutationCrossoversRejectDifferentGenes(t *testing.T) {
+	for _, name := range permutationCrossoverNames {
+		crossover, err := PermutationCrossoverByName[tour](name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := crossover(tour{0, 1, 2}, tour{0, 1, 3}, rand.New(rand.NewSource(1))); err == nil {
+			t.Errorf("%s accepted parents that are not permutations of each other", name)
+		}
+	}
+}
+
+// isPermutation reports whether genes holds every number from 0 to n-1 exactly once.
+func isPermutation(genes tour, n int) bool {
+	if len(genes) != n {
+		return false
+	}
+	sorted := append([]int(nil), genes...)
+	sort.Ints(sorted)
+	for i, gene := range sorted {
+		if gene != i {
+			return false
+		}
+	}
+	return true
+}
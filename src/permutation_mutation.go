@@ -0,0 +1,244 @@
+package src
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// LocusMutation changes genes in place at, or starting from, locus. The built-in
+// mutations are LocusMutations; their methods work on a copy of the individual, which is
+// returned. Permutation mutations keep every gene and only move them around, and leave
+// individuals with fewer than two genes unchanged.
+type LocusMutation[T ~[]G, G any] func(genes T, locus int, rng *rand.Rand) error
+
+// Mutate applies the mutation at one random locus.
+func (lm LocusMutation[T, G]) Mutate(individual T, rng *rand.Rand) (T, error) {
+	mutated := copyGenes(individual)
+	if len(mutated) == 0 {
+		return mutated, nil
+	}
+	if err := lm(mutated, rng.Intn(len(mutated)), rng); err != nil {
+		return nil, err
+	}
+	return mutated, nil
+}
+
+// MutateGenes applies the mutation at every locus with probability rate.
+func (lm LocusMutation[T, G]) MutateGenes(individual T, rate float64, rng *rand.Rand) (T, int, error) {
+	mutated := copyGenes(individual)
+	count := 0
+	for locus := range mutated {
+		if rng.Float64() < rate {
+			if err := lm(mutated, locus, rng); err != nil {
+				return nil, 0, err
+			}
+			count++
+		}
+	}
+	return mutated, count, nil
+}
+
+// otherPosition returns a random position other than locus.
+func otherPosition(n int, locus int, rng *rand.Rand) int {
+	other := rng.Intn(n - 1)
+	if other >= locus {
+		other++
+	}
+	return other
+}
+
+// distinctPositions returns two different random positions in ascending order.
+func distinctPositions(n int, rng *rand.Rand) (int, int) {
+	pos1 := rng.Intn(n)
+	pos2 := otherPosition(n, pos1, rng)
+	return min(pos1, pos2), max(pos1, pos2)
+}
+
+func copyGenes[T ~[]G, G any](individual T) T {
+	mutated := make(T, len(individual))
+	copy(mutated, individual)
+	return mutated
+}
+
+// SwapMutation swaps the gene at the locus with the gene at another random position.
+func SwapMutation[T ~[]G, G any]() LocusMutation[T, G] {
+	return KSwapMutation[T](1)
+}
+
+// KSwapMutation makes k swaps, the first of them at the locus, of genes at two different
+// random positions.
+func KSwapMutation[T ~[]G, G any](k int) LocusMutation[T, G] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		if len(genes) < 2 {
+			return nil
+		}
+
+		for i := 0; i < k; i++ {
+			pos1 := locus
+			if i > 0 {
+				pos1 = rng.Intn(len(genes))
+			}
+			pos2 := otherPosition(len(genes), pos1, rng)
+			genes[pos1], genes[pos2] = genes[pos2], genes[pos1]
+		}
+		return nil
+	}
+}
+
+// InversionMutation reverses the segment between the locus and another random position,
+// the 2-opt move of tour problems.
+func InversionMutation[T ~[]G, G any]() LocusMutation[T, G] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		if len(genes) < 2 {
+			return nil
+		}
+
+		other := otherPosition(len(genes), locus, rng)
+		for start, end := min(locus, other), max(locus, other); start < end; start, end = start+1, end-1 {
+			genes[start], genes[end] = genes[end], genes[start]
+		}
+		return nil
+	}
+}
+
+// InsertionMutation moves the gene at the locus to another random position.
+func InsertionMutation[T ~[]G, G any]() LocusMutation[T, G] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		if len(genes) < 2 {
+			return nil
+		}
+
+		to := otherPosition(len(genes), locus, rng)
+		gene := genes[locus]
+		if locus < to {
+			copy(genes[locus:to], genes[locus+1:to+1])
+		} else {
+			copy(genes[to+1:locus+1], genes[to:locus])
+		}
+		genes[to] = gene
+		return nil
+	}
+}
+
+// DisplacementMutation cuts out a segment of random length starting at the locus and
+// inserts it at another random position.
+func DisplacementMutation[T ~[]G, G any]() LocusMutation[T, G] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		n := len(genes)
+		if n < 2 {
+			return nil
+		}
+
+		length := min(1+rng.Intn(n-locus), n-1)
+		segment := copyGenes(genes[locus : locus+length])
+		rest := make(T, 0, n-length)
+		rest = append(rest, genes[:locus]...)
+		rest = append(rest, genes[locus+length:]...)
+
+		// pick an insertion point that actually moves the segment
+		at := otherPosition(len(rest)+1, locus, rng)
+		displaced := make(T, 0, n)
+		displaced = append(displaced, rest[:at]...)
+		displaced = append(displaced, segment...)
+		displaced = append(displaced, rest[at:]...)
+		copy(genes, displaced)
+		return nil
+	}
+}
+
+// ScrambleMutation shuffles the genes between the locus and another random position.
+func ScrambleMutation[T ~[]G, G any]() LocusMutation[T, G] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		if len(genes) < 2 {
+			return nil
+		}
+
+		other := otherPosition(len(genes), locus, rng)
+		segment := genes[min(locus, other) : max(locus, other)+1]
+		rng.Shuffle(len(segment), func(i, j int) { segment[i], segment[j] = segment[j], segment[i] })
+		return nil
+	}
+}
+
+// WeightedMutation is one choice of MixMutations.
+type WeightedMutation[T any] struct {
+	Operator Mutator[T]
+	Weight   float64
+}
+
+// MixMutations applies one of the given mutations per call, chosen with probability
+// proportional to its weight. Per-gene mutation needs every choice to be a GeneMutator.
+func MixMutations[T any](choices ...WeightedMutation[T]) GeneMutator[T] {
+	total := 0.0
+	for _, choice := range choices {
+		total += choice.Weight
+	}
+	return mixedMutation[T]{choices: choices, total: total}
+}
+
+type mixedMutation[T any] struct {
+	choices []WeightedMutation[T]
+	total   float64
+}
+
+func (mm mixedMutation[T]) choose(rng *rand.Rand) Mutator[T] {
+	threshold := rng.Float64() * mm.total
+	for _, choice := range mm.choices {
+		threshold -= choice.Weight
+		if threshold < 0 {
+			return choice.Operator
+		}
+	}
+	if len(mm.choices) == 0 {
+		return nil
+	}
+	return mm.choices[len(mm.choices)-1].Operator
+}
+
+func (mm mixedMutation[T]) Mutate(individual T, rng *rand.Rand) (T, error) {
+	operator := mm.choose(rng)
+	if operator == nil {
+		return individual, nil
+	}
+	return operator.Mutate(individual, rng)
+}
+
+func (mm mixedMutation[T]) SupportsGeneMutation() bool {
+	for _, choice := range mm.choices {
+		if !SupportsGeneMutation(choice.Operator) {
+			return false
+		}
+	}
+	return true
+}
+
+func (mm mixedMutation[T]) MutateGenes(individual T, rate float64, rng *rand.Rand) (T, int, error) {
+	operator := mm.choose(rng)
+	if operator == nil {
+		return individual, 0, nil
+	}
+	geneMutator, ok := operator.(GeneMutator[T])
+	if !ok {
+		return individual, 0, fmt.Errorf("%T does not support per-gene mutation", operator)
+	}
+	return geneMutator.MutateGenes(individual, rate, rng)
+}
+
+// PermutationMutationByName returns a permutation mutation: "swap", "inversion",
+// "insertion", "displacement" or "scramble".
+func PermutationMutationByName[T ~[]G, G any](name string) (LocusMutation[T, G], error) {
+	switch name {
+	case "swap":
+		return SwapMutation[T](), nil
+	case "inversion":
+		return InversionMutation[T](), nil
+	case "insertion":
+		return InsertionMutation[T](), nil
+	case "displacement":
+		return DisplacementMutation[T](), nil
+	case "scramble":
+		return ScrambleMutation[T](), nil
+	default:
+		return nil, fmt.Errorf("unknown permutation mutation %q", name)
+	}
+}
@@ -0,0 +1,64 @@
+package src
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// runChunks splits the indices [from, to) into chunks of chunkSize and hands them to a pool
+// of workers. With one worker the chunks run one after another in the calling goroutine.
+// The first error returned by work stops the remaining chunks and is returned; otherwise
+// ctx.Err() is returned when ctx is done before every chunk ran.
+func runChunks(ctx context.Context, workers int, from int, to int, work func(ctx context.Context, start int, end int) error) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers == 1 {
+		for start := from; start < to; start += chunkSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := work(ctx, start, min(start+chunkSize, to)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	var errOnce sync.Once
+
+	starts := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				if workCtx.Err() != nil {
+					continue
+				}
+				if err := work(workCtx, start, min(start+chunkSize, to)); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+	for start := from; start < to; start += chunkSize {
+		starts <- start
+	}
+	close(starts)
+	wg.Wait()
+
+	if firstErr != nil && ctx.Err() == nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -1,101 +1,337 @@
 package src
 
 import (
+	"context"
 	"math/rand"
 	"sort"
-	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Population struct {
 	individuals       []Individual
+	fitness           []float64 // fitness[i] belongs to individuals[i]
 	totalFitnessScore float64
+	weights           []float64 // roulette weight of every individual, see FitnessScaling
+	selectorWeights   []float64 // cumulative weights built by a preparingSelector
+	cumulativeWeights []float64 // cumulativeWeights[i] is the sum of weights[:i+1]
+	totalWeight       float64
+	objective         Objective
+	scaling           FitnessScaling
+	selector          Selector
+	crossoverRate     float64
 	mutationRate      float64
+	geneMutationRate  float64 // per-gene mutation probability, 0 means per-individual mutation
 	model             Model
 	popSize           int
 	elitismRate       float64
+	evaluations       int
+	concurrency       int // number of workers, 0 means GOMAXPROCS
+	errorPolicy       ErrorPolicy
+	crossoverErrors   int64
+	mutationErrors    int64
+	mutatedGenes      int64 // genes mutated while breeding the current generation
+	seed              int64
+	generation        int
+	phases            PhaseDurations
 }
 
-func (population *Population) evolve() {
+// evolve breeds and evaluates the next generation on the worker pool, one random source
+// per chunk of offspring. If ctx is cancelled or an operator error aborts breeding, the
+// current generation is kept and the error is returned.
+func (population *Population) evolve(ctx context.Context) error {
+	if err := population.ensureEvaluatedContext(ctx); err != nil {
+		return err
+	}
+	population.sortByFitness()
+	if selector, ok := population.selector.(preparingSelector); ok {
+		selector.prepare(population)
+	}
 	newIndividuals := make([]Individual, population.popSize)
+	newFitness := make([]float64, population.popSize)
 
-	for i := 0; i < len(population.individuals); i++ {
-		parent1 := population.model.SelectParent(population)
-		parent2 := population.model.SelectParent(population)
-		offSpring, _ := population.model.Crossover(parent1, parent2)
+	// Determine the number of elites
+	eliteSize := int(population.elitismRate * float64(population.popSize))
 
-		mutationChance := rand.Float64()
-		if mutationChance <= population.mutationRate {
-			offSpring, _ = population.model.Mutate(offSpring)
-		}
+	// Copy the elites to the new population, their fitness is already known
+	copy(newIndividuals[:eliteSize], population.individuals[:eliteSize])
+	copy(newFitness[:eliteSize], population.fitness[:eliteSize])
+
+	atomic.StoreInt64(&population.mutatedGenes, 0)
+	breedingStart := time.Now()
+	err := runChunks(ctx, population.concurrency, eliteSize, len(newIndividuals), func(ctx context.Context, start int, end int) error {
+		rng := deriveRand(population.seed, population.generation+1, start/chunkSize)
+		for i := start; i < end; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			offSpring, err := population.breed(rng)
+			if err != nil {
+				return err
+			}
 
-		newIndividuals[i] = offSpring
+			// a second child that does not fit into the chunk is dropped
+			i += copy(newIndividuals[i:end], offSpring)
+		}
+		return nil
+	})
+	population.phases.Breeding += time.Since(breedingStart)
+	if err != nil {
+		return err
+	}
+	if err := population.evaluate(ctx, newIndividuals, newFitness, eliteSize); err != nil {
+		return err
 	}
 
-	population.totalFitnessScore = 0.0
 	population.individuals = newIndividuals
+	population.fitness = newFitness
+	population.generation++
+	population.refreshTotals()
+	return nil
 }
 
-func (population *Population) evolveParallel() {
-	newIndividuals := make([]Individual, population.popSize)
+// breed selects two parents and produces one offspring from them, or two when the model is
+// a PairCrossoverModel. Every offspring owns its genes before it is mutated: copies of the
+// parents are made only when crossover is skipped or hands back genes of a parent.
+// Operator failures are counted and then handled according to the population's error
+// policy.
+func (population *Population) breed(rng *rand.Rand) ([]Individual, error) {
+	var err error
+	for attempt := 0; attempt <= maxOperatorRetries; attempt++ {
+		parents := population.selectParents(2, rng)
+		parent1, parent2 := parents[0], parents[1]
+		var offSpring []Individual
+		offSpring, err = population.crossover(parent1, parent2, rng)
+		if err != nil {
+			atomic.AddInt64(&population.crossoverErrors, 1)
+			err = &OperatorError{Operator: "crossover", Err: err}
+		}
+		for i := 0; i < len(offSpring) && err == nil; i++ {
+			offSpring[i], err = population.mutate(offSpring[i], rng)
+		}
+		if err == nil {
+			return offSpring, nil
+		}
 
-	sort.SliceStable(population.individuals, func(i, j int) bool {
-		return population.individuals[i].CalculateFitness() > population.individuals[j].CalculateFitness()
-	})
+		switch population.errorPolicy {
+		case RetryOnError:
+			continue
+		case FallbackOnError:
+			return []Individual{cloneIndividual(parent1)}, nil
+		default:
+			return nil, err
+		}
+	}
 
-	// Determine the number of elites
-	eliteSize := int(population.elitismRate * float64(population.popSize))
+	return nil, err
+}
 
-	// Copy the elites to the new population
-	copy(newIndividuals[:eliteSize], population.individuals[:eliteSize])
+func (population *Population) crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) ([]Individual, error) {
+	pairModel, pair := population.model.(PairCrossoverModel)
+	if population.crossoverRate < 1 && !(rng.Float64() < population.crossoverRate) {
+		if pair {
+			return []Individual{cloneIndividual(parent1), cloneIndividual(parent2)}, nil
+		}
+		return []Individual{cloneIndividual(parent1)}, nil
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(len(population.individuals) - eliteSize)
-	for i := eliteSize; i < len(population.individuals); i++ {
-		go func(index int) {
-			defer wg.Done() // decrement the counter when Goroutine is done
+	var children []Individual
+	var err error
+	if pair {
+		var child1, child2 Individual
+		child1, child2, err = pairModel.CrossoverPair(parent1, parent2, rng)
+		children = []Individual{child1, child2}
+	} else {
+		var child Individual
+		child, err = population.model.Crossover(parent1, parent2, rng)
+		children = []Individual{child}
+	}
+	if err != nil {
+		return nil, err
+	}
 
-			parent1 := population.model.SelectParent(population)
-			parent2 := population.model.SelectParent(population)
-			offSpring, _ := population.model.Crossover(parent1, parent2)
+	for i, child := range children {
+		if sharesGenes(child, parent1) || sharesGenes(child, parent2) || (i == 1 && sharesGenes(child, children[0])) {
+			children[i] = cloneIndividual(child)
+		}
+	}
+	return children, nil
+}
 
-			mutationChance := rand.Float64()
-			if mutationChance <= population.mutationRate {
-				offSpring, _ = population.model.Mutate(offSpring)
-			}
+// mutate applies per-gene mutation when a gene mutation rate is set, and otherwise mutates
+// the offspring with probability mutationRate. The genes that changed are counted; when
+// the mutation changed the offspring in place, the count the operator reports is used,
+// which is one for Mutate.
+func (population *Population) mutate(offSpring Individual, rng *rand.Rand) (Individual, error) {
+	var mutated Individual
+	var err error
+	reported := 1
+	if population.geneMutationRate > 0 {
+		mutated, reported, err = population.model.(GeneMutationModel).MutateGenes(offSpring, population.geneMutationRate, rng)
+	} else if rng.Float64() <= population.mutationRate {
+		mutated, err = population.model.Mutate(offSpring, rng)
+	} else {
+		return offSpring, nil
+	}
+	if err != nil {
+		atomic.AddInt64(&population.mutationErrors, 1)
+		return nil, &OperatorError{Operator: "mutation", Err: err}
+	}
 
-			newIndividuals[index] = offSpring
-		}(i) // passing i as an argument to the Goroutine
+	changed, ok := changedGenes(offSpring, mutated)
+	if !ok {
+		changed = reported
 	}
-	wg.Wait() // block until all Goroutines finish
+	atomic.AddInt64(&population.mutatedGenes, int64(changed))
+	return mutated, nil
+}
 
-	population.totalFitnessScore = 0.0
-	population.individuals = newIndividuals
+// evaluate calculates fitness[i] of every individual from index from on, on the worker
+// pool. It stops early with ctx.Err() when ctx is done; the evaluations made until then
+// are counted.
+func (population *Population) evaluate(ctx context.Context, individuals []Individual, fitness []float64, from int) error {
+	evaluationStart := time.Now()
+	defer func() { population.phases.Evaluation += time.Since(evaluationStart) }()
+
+	var evaluations int64
+	err := runChunks(ctx, population.concurrency, from, len(individuals), func(ctx context.Context, start int, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			fitness[i] = individuals[i].CalculateFitness()
+			atomic.AddInt64(&evaluations, 1)
+		}
+		return nil
+	})
+
+	population.evaluations += int(evaluations)
+	return err
 }
 
-func (population *Population) calculateBestIndividual() Individual {
-	bestFitnessScore := 0.0
-	var bestIndividual Individual
-	for _, individual := range population.individuals {
-		currentFitnessScore := individual.CalculateFitness()
-		if currentFitnessScore > bestFitnessScore {
-			bestIndividual = individual
-			bestFitnessScore = currentFitnessScore
+// refreshTotals recalculates the total fitness score and the selection weights from the
+// known fitness values.
+func (population *Population) refreshTotals() {
+	population.totalFitnessScore = 0.0
+	for _, fitness := range population.fitness {
+		population.totalFitnessScore += fitness
+	}
+
+	scores := make([]float64, len(population.fitness))
+	population.objective.scores(population.fitness, scores)
+	population.weights = make([]float64, len(population.fitness))
+	if population.scaling != nil {
+		population.scaling.Scale(population.generation, scores, population.weights)
+	} else {
+		NoScaling{}.Scale(population.generation, scores, population.weights)
+	}
+
+	total := 0.0
+	for i, weight := range population.weights {
+		if !(weight > 0) {
+			population.weights[i] = 0
+		}
+		total += population.weights[i]
+	}
+	if total == 0 {
+		for i := range population.weights {
+			population.weights[i] = 1
 		}
 	}
 
-	return bestIndividual
+	// The table is rebuilt, never updated, so workers can search it while breeding.
+	population.cumulativeWeights = make([]float64, len(population.weights))
+	population.totalWeight = 0.0
+	for i, weight := range population.weights {
+		population.totalWeight += weight
+		population.cumulativeWeights[i] = population.totalWeight
+	}
 }
 
-func (population *Population) getTotalFitnessScore() float64 {
-	totalFitnessScore := population.totalFitnessScore
-	if totalFitnessScore != 0 {
-		return totalFitnessScore
+// searchWeight returns the index of the individual whose slice of the roulette wheel
+// contains position, a value in [0, totalWeight). It takes O(log n) time.
+func (population *Population) searchWeight(position float64) int {
+	index := sort.Search(len(population.cumulativeWeights), func(i int) bool {
+		return population.cumulativeWeights[i] > position
+	})
+	return min(index, len(population.cumulativeWeights)-1)
+}
+
+// ensureEvaluated evaluates the initial individuals the first time it is called.
+func (population *Population) ensureEvaluated() {
+	_ = population.ensureEvaluatedContext(context.Background())
+}
+
+// ensureEvaluatedContext is ensureEvaluated that gives up when ctx is done, leaving the
+// individuals unevaluated.
+func (population *Population) ensureEvaluatedContext(ctx context.Context) error {
+	if len(population.fitness) == len(population.individuals) {
+		return nil
 	}
 
-	for _, individual := range population.individuals {
-		totalFitnessScore += individual.CalculateFitness()
+	fitness := make([]float64, len(population.individuals))
+	if err := population.evaluate(ctx, population.individuals, fitness, 0); err != nil {
+		return err
 	}
-	population.totalFitnessScore = totalFitnessScore
+	population.fitness = fitness
+	population.refreshTotals()
+	return nil
+}
 
-	return totalFitnessScore
+// sortByFitness orders individuals and their fitness from best to worst.
+func (population *Population) sortByFitness() {
+	order := make([]int, len(population.individuals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return population.objective.better(population.fitness[order[i]], population.fitness[order[j]])
+	})
+
+	individuals := make([]Individual, len(order))
+	fitness := make([]float64, len(order))
+	for i, index := range order {
+		individuals[i] = population.individuals[index]
+		fitness[i] = population.fitness[index]
+	}
+	population.individuals = individuals
+	population.fitness = fitness
+	population.refreshTotals()
+}
+
+// calculateBestIndividual returns the individual with the best fitness according to the
+// objective, or nil for an empty population.
+func (population *Population) calculateBestIndividual() Individual {
+	best := population.bestIndex()
+	if best < 0 {
+		return nil
+	}
+	return population.individuals[best]
+}
+
+func (population *Population) calculateBestFitness() float64 {
+	best := population.bestIndex()
+	if best < 0 {
+		return 0
+	}
+	return population.fitness[best]
+}
+
+func (population *Population) bestIndex() int {
+	population.ensureEvaluated()
+	best := -1
+	for i, fitness := range population.fitness {
+		if best < 0 || population.objective.better(fitness, population.fitness[best]) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// getTotalFitnessScore is the sum of the fitness of all individuals. It is calculated
+// once per generation by evaluate, so concurrent readers never recompute it.
+func (population *Population) getTotalFitnessScore() float64 {
+	return population.totalFitnessScore
 }
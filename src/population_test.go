@@ -0,0 +1,92 @@
+package src
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// route is a struct Genome; its fitness is the number of genes already in place.
+type route struct {
+	Genes []int
+}
+
+func (r route) CalculateFitness() float64 {
+	return tour(r.Genes).CalculateFitness()
+}
+
+func (r route) GenerateIndividual(rng *rand.Rand) Individual {
+	return route{Genes: rng.Perm(10)}
+}
+
+func (r route) Len() int                    { return len(r.Genes) }
+func (r route) Get(i int) interface{}       { return r.Genes[i] }
+func (r route) Set(i int, gene interface{}) { r.Genes[i] = gene.(int) }
+func (r route) Swap(i int, j int)           { r.Genes[i], r.Genes[j] = r.Genes[j], r.Genes[i] }
+func (r route) Clone() Individual           { return route{Genes: append([]int(nil), r.Genes...)} }
+
+// aliasingModel hands back the first parent as the child and mutates in place, which the
+// Model contract allows.
+type aliasingModel struct {
+	DefaultModel
+}
+
+func (aliasingModel) Crossover(parent1 Individual, parent2 Individual, rng *rand.Rand) (Individual, error) {
+	return parent1, nil
+}
+
+func (aliasingModel) Mutate(individual Individual, rng *rand.Rand) (Individual, error) {
+	genes := individual.(route)
+	genes.Swap(rng.Intn(genes.Len()), rng.Intn(genes.Len()))
+	return genes, nil
+}
+
+func TestOffspringDoNotShareGenesWithParents(t *testing.T) {
+	ga, err := NewGA(route{},
+		WithPopulationSize(50),
+		WithGenerations(20),
+		WithSeed(7),
+		WithElitismRate(0.2),
+		WithMutationRate(1),
+		WithModel(aliasingModel{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ga.RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, individual := range result.FinalPopulation {
+		if fitness := individual.CalculateFitness(); fitness != result.FinalFitness[i] {
+			t.Errorf("individual %d has fitness %v, but %v is recorded", i, fitness, result.FinalFitness[i])
+		}
+	}
+	if fitness := result.Best.CalculateFitness(); fitness != result.BestFitness {
+		t.Errorf("best individual has fitness %v, but %v is recorded", fitness, result.BestFitness)
+	}
+}
+
+func TestInPlaceMutationOfStructGenomesIsCounted(t *testing.T) {
+	ga, err := NewGA(route{},
+		WithPopulationSize(50),
+		WithGenerations(3),
+		WithSeed(7),
+		WithElitismRate(0.2),
+		WithMutationRate(1),
+		WithModel(aliasingModel{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ga.RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every offspring is mutated once, and Mutate reports one gene for it.
+	for _, stats := range result.History[1:] {
+		if stats.MutatedGenes != 40 {
+			t.Errorf("generation %d: %d mutated genes, want 40", stats.Generation, stats.MutatedGenes)
+		}
+	}
+}
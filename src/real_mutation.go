@@ -0,0 +1,163 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Interval is the range [Min, Max] a real-valued gene may take.
+type Interval struct {
+	Min float64
+	Max float64
+}
+
+// Bounds holds the interval of every gene. A single interval applies to all genes.
+type Bounds []Interval
+
+// BoundsRepair decides how a gene that left its interval is brought back.
+type BoundsRepair int
+
+const (
+	// Clamp moves the gene to the nearest end of its interval.
+	Clamp BoundsRepair = iota
+	// Reflect mirrors the gene at the end it crossed, as often as needed.
+	Reflect
+	// Wrap treats the interval as a circle.
+	Wrap
+	// Resample draws the gene again, uniformly from its interval.
+	Resample
+)
+
+var errNoBounds = errors.New("mutation needs bounds")
+
+// interval returns the interval of gene i of an individual with n genes.
+func (b Bounds) interval(i int, n int) (Interval, error) {
+	switch len(b) {
+	case 1:
+		return b[0], nil
+	case n:
+		return b[i], nil
+	default:
+		return Interval{}, fmt.Errorf("%d bounds for %d genes", len(b), n)
+	}
+}
+
+func (r BoundsRepair) repair(gene float64, interval Interval, rng *rand.Rand) float64 {
+	if gene >= interval.Min && gene <= interval.Max {
+		return gene
+	}
+
+	width := interval.Max - interval.Min
+	if width <= 0 {
+		return interval.Min
+	}
+	switch r {
+	case Reflect:
+		offset := math.Mod(gene-interval.Min, 2*width)
+		if offset < 0 {
+			offset += 2 * width
+		}
+		if offset > width {
+			offset = 2*width - offset
+		}
+		return interval.Min + offset
+	case Wrap:
+		offset := math.Mod(gene-interval.Min, width)
+		if offset < 0 {
+			offset += width
+		}
+		return interval.Min + offset
+	case Resample:
+		return interval.Min + rng.Float64()*width
+	default:
+		return math.Min(math.Max(gene, interval.Min), interval.Max)
+	}
+}
+
+// realMutation changes the gene at the locus with change and repairs it. Bounds may be
+// nil when requireBounds is false; the gene is then not repaired.
+func realMutation[T ~[]float64](bounds Bounds, repair BoundsRepair, requireBounds bool, change func(gene float64, interval Interval, rng *rand.Rand) float64) LocusMutation[T, float64] {
+	return func(genes T, locus int, rng *rand.Rand) error {
+		if bounds == nil && requireBounds {
+			return errNoBounds
+		}
+
+		interval := Interval{Min: math.Inf(-1), Max: math.Inf(1)}
+		if bounds != nil {
+			var err error
+			if interval, err = bounds.interval(locus, len(genes)); err != nil {
+				return err
+			}
+		}
+		genes[locus] = repair.repair(change(genes[locus], interval, rng), interval, rng)
+		return nil
+	}
+}
+
+// GaussianMutation adds normally distributed noise with standard deviation sigma to the
+// gene at the locus. Bounds may be nil.
+func GaussianMutation[T ~[]float64](sigma float64, bounds Bounds, repair BoundsRepair) LocusMutation[T, float64] {
+	return realMutation[T](bounds, repair, false, func(gene float64, _ Interval, rng *rand.Rand) float64 {
+		return gene + rng.NormFloat64()*sigma
+	})
+}
+
+// CauchyMutation adds Cauchy distributed noise with the given scale to the gene at the
+// locus. Its heavy tails make long jumps more likely than with GaussianMutation. Bounds
+// may be nil.
+func CauchyMutation[T ~[]float64](scale float64, bounds Bounds, repair BoundsRepair) LocusMutation[T, float64] {
+	return realMutation[T](bounds, repair, false, func(gene float64, _ Interval, rng *rand.Rand) float64 {
+		return gene + scale*math.Tan(math.Pi*(rng.Float64()-0.5))
+	})
+}
+
+// UniformMutation replaces the gene at the locus with a value drawn uniformly from its
+// interval.
+func UniformMutation[T ~[]float64](bounds Bounds, repair BoundsRepair) LocusMutation[T, float64] {
+	return realMutation[T](bounds, repair, true, func(_ float64, interval Interval, rng *rand.Rand) float64 {
+		return interval.Min + rng.Float64()*(interval.Max-interval.Min)
+	})
+}
+
+// PolynomialMutation is the polynomial mutation of NSGA-II with distribution index eta.
+// Larger eta keeps the mutated gene closer to its old value.
+func PolynomialMutation[T ~[]float64](eta float64, bounds Bounds, repair BoundsRepair) LocusMutation[T, float64] {
+	return realMutation[T](bounds, repair, true, func(gene float64, interval Interval, rng *rand.Rand) float64 {
+		width := interval.Max - interval.Min
+		if width <= 0 {
+			return interval.Min
+		}
+
+		delta1 := (gene - interval.Min) / width
+		delta2 := (interval.Max - gene) / width
+		power := 1 / (eta + 1)
+		u := rng.Float64()
+		var deltaq float64
+		if u < 0.5 {
+			v := 2*u + (1-2*u)*math.Pow(1-delta1, eta+1)
+			deltaq = math.Pow(v, power) - 1
+		} else {
+			v := 2*(1-u) + 2*(u-0.5)*math.Pow(1-delta2, eta+1)
+			deltaq = 1 - math.Pow(v, power)
+		}
+		return gene + deltaq*width
+	})
+}
+
+// NonUniformMutation is Michalewicz's non-uniform mutation: the gene at the locus moves
+// towards one end of its interval by a step that shrinks as generation() approaches
+// maxGenerations. b controls how fast the step shrinks. generation is usually GA.Generation.
+func NonUniformMutation[T ~[]float64](b float64, maxGenerations int, generation func() int, bounds Bounds, repair BoundsRepair) LocusMutation[T, float64] {
+	return realMutation[T](bounds, repair, true, func(gene float64, interval Interval, rng *rand.Rand) float64 {
+		progress := math.Min(float64(generation())/float64(maxGenerations), 1)
+		step := func(distance float64) float64 {
+			return distance * (1 - math.Pow(rng.Float64(), math.Pow(1-progress, b)))
+		}
+		if rng.Intn(2) == 0 {
+			return gene + step(interval.Max-gene)
+		}
+		return gene - step(gene-interval.Min)
+	})
+}
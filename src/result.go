@@ -0,0 +1,26 @@
+package src
+
+import "time"
+
+// RunResult describes a finished run. Best is the best individual of every run of the GA
+// so far; the counts, durations and history cover this run only.
+type RunResult struct {
+	Best              Individual
+	BestFitness       float64
+	BestGeneration    int // generation in which Best was found
+	Generations       int // generation the population reached
+	Evaluations       int // fitness evaluations made in this run
+	Duration          time.Duration
+	Phases            PhaseDurations
+	TerminationReason string
+	OperatorErrors    OperatorErrorCounts
+	FinalPopulation   []Individual
+	FinalFitness      []float64         // FinalFitness[i] belongs to FinalPopulation[i]
+	History           []GenerationStats // the generation the run started from and every generation it bred
+}
+
+// PhaseDurations is the time a run spent in each phase of the generations it bred.
+type PhaseDurations struct {
+	Breeding   time.Duration
+	Evaluation time.Duration
+}
@@ -0,0 +1,31 @@
+package src
+
+import (
+	"math/rand"
+	"time"
+)
+
+// chunkSize is the number of individuals that share one random source. Work is split into
+// chunks the same way whatever the number of workers, so a seed always gives the same run.
+const chunkSize = 64
+
+// newSeed picks a seed for runs that were not given one.
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// deriveRand returns the random source of one chunk of one generation.
+func deriveRand(seed int64, generation int, chunk int) *rand.Rand {
+	x := splitMix64(uint64(seed))
+	x = splitMix64(x ^ uint64(generation))
+	x = splitMix64(x ^ uint64(chunk))
+
+	return rand.New(rand.NewSource(int64(x)))
+}
+
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,159 @@
+package src
+
+import (
+	"math"
+	"sort"
+)
+
+// FitnessScaling turns the scores of a generation into roulette weights before parents are
+// selected. Scores are fitness values oriented so that higher is better whatever the
+// objective. Negative weights are treated as zero, and when every weight is zero all
+// individuals get the same weight.
+type FitnessScaling interface {
+	Scale(generation int, scores []float64, weights []float64)
+}
+
+// NoScaling uses scores as they are when none is negative, and otherwise shifts them
+// against the worst individual, which then gets weight zero. It is the default.
+type NoScaling struct {
+}
+
+func (ns NoScaling) Scale(generation int, scores []float64, weights []float64) {
+	lowest := 0.0
+	for _, score := range scores {
+		lowest = min(lowest, score)
+	}
+	for i, score := range scores {
+		weights[i] = score - lowest
+	}
+}
+
+// LinearScaling maps scores to a*f+b so that the mean keeps its weight and the best
+// individual gets Multiplier times the mean weight, 1.2 to 2 being usual. When that would
+// make the worst weight negative, the worst weight is set to zero instead.
+type LinearScaling struct {
+	Multiplier float64
+}
+
+func (ls LinearScaling) Scale(generation int, scores []float64, weights []float64) {
+	NoScaling{}.Scale(generation, scores, weights)
+	mean, lowest, highest := summarize(weights)
+	if highest == mean {
+		return
+	}
+
+	var a, b float64
+	if lowest > (ls.Multiplier*mean-highest)/(ls.Multiplier-1) {
+		a = (ls.Multiplier - 1) * mean / (highest - mean)
+		b = mean * (highest - ls.Multiplier*mean) / (highest - mean)
+	} else {
+		a = mean / (mean - lowest)
+		b = -lowest * mean / (mean - lowest)
+	}
+	for i, weight := range weights {
+		weights[i] = a*weight + b
+	}
+}
+
+// SigmaTruncation subtracts mean - C*stddev from every score, so individuals more than
+// C standard deviations below the mean are never selected.
+type SigmaTruncation struct {
+	C float64
+}
+
+func (st SigmaTruncation) Scale(generation int, scores []float64, weights []float64) {
+	mean, _, _ := summarize(scores)
+	variance := 0.0
+	for _, score := range scores {
+		variance += (score - mean) * (score - mean)
+	}
+	sigma := math.Sqrt(variance / float64(len(scores)))
+
+	for i, score := range scores {
+		weights[i] = math.Max(0, score-(mean-st.C*sigma))
+	}
+}
+
+// PowerLawScaling raises the NoScaling weights to the power K. K above 1 increases
+// selection pressure, K below 1 decreases it.
+type PowerLawScaling struct {
+	K float64
+}
+
+func (pl PowerLawScaling) Scale(generation int, scores []float64, weights []float64) {
+	NoScaling{}.Scale(generation, scores, weights)
+	for i, weight := range weights {
+		weights[i] = math.Pow(weight, pl.K)
+	}
+}
+
+// RankScaling is linear ranking: weights only depend on the rank of a score. The best
+// individual gets Pressure and the worst 2-Pressure, with Pressure between 1 and 2.
+type RankScaling struct {
+	Pressure float64
+}
+
+func (rs RankScaling) Scale(generation int, scores []float64, weights []float64) {
+	n := len(scores)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] < scores[order[j]]
+	})
+
+	for rank, index := range order {
+		if n == 1 {
+			weights[index] = 1
+			continue
+		}
+		weights[index] = 2 - rs.Pressure + 2*(rs.Pressure-1)*float64(rank)/float64(n-1)
+	}
+}
+
+// WindowScaling subtracts the worst score of the last Generations generations, the
+// current one included, from every score.
+type WindowScaling struct {
+	Generations int
+	worst       map[int]float64
+}
+
+// NewWindowScaling returns a WindowScaling over the last k generations.
+func NewWindowScaling(k int) *WindowScaling {
+	return &WindowScaling{Generations: k, worst: make(map[int]float64)}
+}
+
+func (ws *WindowScaling) Scale(generation int, scores []float64, weights []float64) {
+	if ws.worst == nil {
+		ws.worst = make(map[int]float64)
+	}
+	_, lowest, _ := summarize(scores)
+	ws.worst[generation] = lowest
+
+	windowWorst := lowest
+	for g, worst := range ws.worst {
+		if g <= generation-ws.Generations {
+			delete(ws.worst, g)
+			continue
+		}
+		windowWorst = min(windowWorst, worst)
+	}
+	for i, score := range scores {
+		weights[i] = score - windowWorst
+	}
+}
+
+func summarize(values []float64) (mean float64, lowest float64, highest float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	lowest, highest = values[0], values[0]
+	for _, value := range values {
+		mean += value
+		lowest = min(lowest, value)
+		highest = max(highest, value)
+	}
+
+	return mean / float64(len(values)), lowest, highest
+}
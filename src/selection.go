@@ -0,0 +1,248 @@
+package src
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Selector picks parents from a population. It replaces Model.SelectParent when set with
+// WithSelector. While offspring are bred, the population is ordered from best to worst
+// individual, so index 0 is always the best. Select is called concurrently by the workers
+// and must not modify the population.
+type Selector interface {
+	// Select picks n parents, drawing only from rng.
+	Select(population *Population, n int, rng *rand.Rand) []Individual
+}
+
+// Len is the number of individuals in the population.
+func (population *Population) Len() int {
+	return len(population.individuals)
+}
+
+// Individual returns the i-th individual.
+func (population *Population) Individual(i int) Individual {
+	return population.individuals[i]
+}
+
+// Fitness returns the fitness of the i-th individual.
+func (population *Population) Fitness(i int) float64 {
+	return population.fitness[i]
+}
+
+// Weight returns the roulette weight of the i-th individual after fitness scaling.
+func (population *Population) Weight(i int) float64 {
+	return population.weights[i]
+}
+
+// Objective tells whether the population is maximizing or minimizing fitness.
+func (population *Population) Objective() Objective {
+	return population.objective
+}
+
+// preparingSelector is implemented by selectors that build a table once per generation,
+// before offspring are bred, instead of on every Select.
+type preparingSelector interface {
+	prepare(population *Population)
+}
+
+// selectParents picks n parents with the configured selector, or with the model when
+// there is none.
+func (population *Population) selectParents(n int, rng *rand.Rand) []Individual {
+	if population.selector != nil {
+		return population.selector.Select(population, n, rng)
+	}
+
+	parents := make([]Individual, n)
+	for i := range parents {
+		parents[i] = population.model.SelectParent(population, rng)
+	}
+	return parents
+}
+
+// RouletteSelection is fitness proportionate selection on the scaled weights, the same
+// as DefaultModel.SelectParent.
+type RouletteSelection struct {
+}
+
+func (rs RouletteSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	parents := make([]Individual, n)
+	for i := range parents {
+		parents[i] = DefaultModel{}.SelectParent(population, rng)
+	}
+	return parents
+}
+
+// TournamentSelection draws Size random individuals and picks the best of them with
+// Probability, the second best with Probability*(1-Probability), and so on. Probability 1
+// is the deterministic tournament.
+type TournamentSelection struct {
+	Size        int
+	Probability float64
+}
+
+func (ts TournamentSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	parents := make([]Individual, n)
+	contestants := make([]int, ts.Size)
+	for i := range parents {
+		for j := range contestants {
+			contestants[j] = rng.Intn(population.Len())
+		}
+		// the population is ordered from best to worst
+		sort.Ints(contestants)
+
+		winner := contestants[len(contestants)-1]
+		for _, contestant := range contestants[:len(contestants)-1] {
+			if rng.Float64() < ts.Probability {
+				winner = contestant
+				break
+			}
+		}
+		parents[i] = population.individuals[winner]
+	}
+	return parents
+}
+
+// LinearRankingSelection selects by rank only. The best individual is Pressure times as
+// likely to be picked as the average one, and the worst 2-Pressure times, with Pressure
+// between 1 and 2.
+type LinearRankingSelection struct {
+	Pressure float64
+}
+
+func (lr LinearRankingSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	size := population.Len()
+	parents := make([]Individual, n)
+	for i := range parents {
+		if size == 1 || lr.Pressure == 1 {
+			parents[i] = population.individuals[rng.Intn(size)]
+			continue
+		}
+
+		// Invert the cumulative distribution of the rank, counted from the worst individual.
+		a := 2 - lr.Pressure
+		b := 2 * (lr.Pressure - 1)
+		u := rng.Float64()
+		x := (-a + math.Sqrt(a*a+2*b*u)) / b
+		rank := min(int(x*float64(size)), size-1)
+		parents[i] = population.individuals[size-1-rank]
+	}
+	return parents
+}
+
+// ExponentialRankingSelection selects by rank only, the individual at rank i, counted from
+// the best, being Base times as likely to be picked as the one at rank i-1. Base is
+// between 0 and 1; smaller values mean higher selection pressure.
+type ExponentialRankingSelection struct {
+	Base float64
+}
+
+func (er ExponentialRankingSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	size := population.Len()
+	parents := make([]Individual, n)
+	for i := range parents {
+		// Invert the cumulative distribution of a geometric distribution cut at size.
+		u := rng.Float64()
+		rank := int(math.Log(1-u*(1-math.Pow(er.Base, float64(size)))) / math.Log(er.Base))
+		parents[i] = population.individuals[min(max(rank, 0), size-1)]
+	}
+	return parents
+}
+
+// StochasticUniversalSampling is fitness proportionate selection with n equally spaced
+// pointers on one spin of the wheel, so the parents of a call match their expected
+// share more closely than n independent roulette spins.
+type StochasticUniversalSampling struct {
+}
+
+func (sus StochasticUniversalSampling) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	parents := make([]Individual, n)
+	spacing := population.totalWeight / float64(n)
+	pointer := rng.Float64() * spacing
+	for i := range parents {
+		parents[i] = population.individuals[population.searchWeight(pointer)]
+		pointer += spacing
+	}
+
+	// pointers are visited in population order, shuffle so parents are not always best first
+	rng.Shuffle(len(parents), func(i, j int) { parents[i], parents[j] = parents[j], parents[i] })
+	return parents
+}
+
+// TruncationSelection picks uniformly among the best Proportion of the population.
+type TruncationSelection struct {
+	Proportion float64
+}
+
+func (ts TruncationSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	top := max(1, int(ts.Proportion*float64(population.Len())))
+	parents := make([]Individual, n)
+	for i := range parents {
+		parents[i] = population.individuals[rng.Intn(top)]
+	}
+	return parents
+}
+
+// BoltzmannSelection picks individuals with probability proportional to exp(f/T), where
+// f is fitness oriented so that higher is better. The temperature starts at Temperature
+// and is multiplied by Cooling every generation; Cooling 0 keeps it constant.
+type BoltzmannSelection struct {
+	Temperature float64
+	Cooling     float64
+}
+
+// prepare builds the cumulative Boltzmann weights of the generation, which Select binary
+// searches.
+func (bs BoltzmannSelection) prepare(population *Population) {
+	population.selectorWeights = bs.cumulativeWeights(population)
+}
+
+func (bs BoltzmannSelection) cumulativeWeights(population *Population) []float64 {
+	temperature := bs.Temperature
+	if bs.Cooling > 0 {
+		temperature *= math.Pow(bs.Cooling, float64(population.generation))
+	}
+
+	scores := make([]float64, population.Len())
+	population.objective.scores(population.fitness, scores)
+	// subtract the best score so that exp never overflows
+	best := math.Inf(-1)
+	for _, score := range scores {
+		best = max(best, score)
+	}
+	cumulative := make([]float64, len(scores))
+	total := 0.0
+	for i, score := range scores {
+		total += math.Exp((score - best) / temperature)
+		cumulative[i] = total
+	}
+	return cumulative
+}
+
+func (bs BoltzmannSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	cumulative := population.selectorWeights
+	if len(cumulative) != population.Len() {
+		// not prepared by evolve
+		cumulative = bs.cumulativeWeights(population)
+	}
+
+	parents := make([]Individual, n)
+	for i := range parents {
+		threshold := rng.Float64() * cumulative[len(cumulative)-1]
+		index := sort.Search(len(cumulative), func(j int) bool { return cumulative[j] > threshold })
+		parents[i] = population.individuals[min(index, len(cumulative)-1)]
+	}
+	return parents
+}
+
+// RandomSelection picks parents uniformly at random, without regard to fitness.
+type RandomSelection struct {
+}
+
+func (rs RandomSelection) Select(population *Population, n int, rng *rand.Rand) []Individual {
+	parents := make([]Individual, n)
+	for i := range parents {
+		parents[i] = population.individuals[rng.Intn(population.Len())]
+	}
+	return parents
+}
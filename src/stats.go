@@ -0,0 +1,139 @@
+package src
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// GenerationStats describes the fitness distribution of one generation.
+type GenerationStats struct {
+	Generation        int
+	BestFitness       float64
+	WorstFitness      float64
+	MeanFitness       float64
+	MedianFitness     float64
+	StandardDeviation float64
+	Diversity         float64       // share of distinct individuals; 0 when not measured, see WithDiversityStats
+	Elapsed           time.Duration // time since the run started
+	Evaluations       int           // fitness evaluations made so far
+	MutatedGenes      int           // genes changed by mutation while breeding this generation
+}
+
+// DiversityKeyer is implemented by individuals that tell themselves apart for diversity:
+// two individuals are the same exactly when their keys are equal. Other individuals are
+// compared by their genes, see Genome.
+type DiversityKeyer interface {
+	DiversityKey() string
+}
+
+// stats describes the current generation. Diversity is measured only when withDiversity
+// is set, as it needs a key for every individual.
+func (population *Population) stats(withDiversity bool) GenerationStats {
+	population.ensureEvaluated()
+	stats := GenerationStats{
+		Generation:   population.generation,
+		Evaluations:  population.evaluations,
+		MutatedGenes: int(atomic.LoadInt64(&population.mutatedGenes)),
+	}
+	if withDiversity {
+		stats.Diversity = population.diversity()
+	}
+	n := len(population.fitness)
+	if n == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, population.fitness)
+	sort.Float64s(sorted)
+	stats.WorstFitness, stats.BestFitness = sorted[0], sorted[n-1]
+	if population.objective == Minimize {
+		stats.WorstFitness, stats.BestFitness = stats.BestFitness, stats.WorstFitness
+	}
+	if n%2 == 1 {
+		stats.MedianFitness = sorted[n/2]
+	} else {
+		stats.MedianFitness = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	stats.MeanFitness = population.getTotalFitnessScore() / float64(n)
+	variance := 0.0
+	for _, fitness := range population.fitness {
+		d := fitness - stats.MeanFitness
+		variance += d * d
+	}
+	stats.StandardDeviation = math.Sqrt(variance / float64(n))
+
+	return stats
+}
+
+// diversity is the share of distinct individuals in the population, from 1/n when every
+// individual is the same up to 1 when all of them differ. Individuals are told apart by
+// diversityKey.
+func (population *Population) diversity() float64 {
+	n := len(population.individuals)
+	if n == 0 {
+		return 0
+	}
+
+	distinct := make(map[string]struct{}, n)
+	var key strings.Builder
+	for _, individual := range population.individuals {
+		key.Reset()
+		diversityKey(&key, individual)
+		distinct[key.String()] = struct{}{}
+	}
+
+	return float64(len(distinct)) / float64(n)
+}
+
+// DiversityKey is the key diversity tells individual apart by: its DiversityKey when it is
+// a DiversityKeyer, and otherwise the values of its genes. Individuals that are neither a
+// Genome nor a slice are keyed by their printed value.
+func DiversityKey(individual interface{}) string {
+	var key strings.Builder
+	diversityKey(&key, individual)
+	return key.String()
+}
+
+func diversityKey(key *strings.Builder, individual interface{}) {
+	if keyer, ok := individual.(DiversityKeyer); ok {
+		key.WriteString(keyer.DiversityKey())
+		return
+	}
+	genes, err := genomeOf(individual)
+	if err != nil {
+		fmt.Fprintf(key, "%v", individual)
+		return
+	}
+
+	var buf [24]byte
+	for i := 0; i < genes.Len(); i++ {
+		switch gene := genes.Get(i).(type) {
+		case int:
+			key.Write(strconv.AppendInt(buf[:0], int64(gene), 36))
+		case int32:
+			key.Write(strconv.AppendInt(buf[:0], int64(gene), 36))
+		case int64:
+			key.Write(strconv.AppendInt(buf[:0], gene, 36))
+		case uint8:
+			key.WriteByte(gene)
+		case float64:
+			binary.LittleEndian.PutUint64(buf[:], math.Float64bits(gene))
+			key.Write(buf[:8])
+		case bool:
+			key.WriteString(strconv.FormatBool(gene))
+		case string:
+			key.WriteString(strconv.Quote(gene))
+		default:
+			fmt.Fprintf(key, "%v", gene)
+		}
+		key.WriteByte(0)
+	}
+}
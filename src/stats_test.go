@@ -0,0 +1,33 @@
+package src
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDiversityStatsAreRecordedEveryGeneration(t *testing.T) {
+	result, err := newTestGA(t, WithDiversityStats()).RunWithResult(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stats := range result.History {
+		if !(stats.Diversity > 0 && stats.Diversity <= 1) {
+			t.Errorf("generation %d: diversity %v, want a share in (0, 1]", stats.Generation, stats.Diversity)
+		}
+	}
+	if first := result.History[0].Diversity; first != 1 {
+		t.Errorf("initial diversity %v, want 1 for random permutations", first)
+	}
+}
+
+func TestDiversityKeyComparesGenes(t *testing.T) {
+	if DiversityKey(tour{1, 2, 30}) != DiversityKey(tour{1, 2, 30}) {
+		t.Error("equal genes have different keys")
+	}
+	if DiversityKey(tour{1, 23}) == DiversityKey(tour{12, 3}) {
+		t.Error("different genes have the same key")
+	}
+	if DiversityKey(route{Genes: []int{1, 2}}) != DiversityKey(route{Genes: []int{1, 2}}) {
+		t.Error("equal struct genomes have different keys")
+	}
+}
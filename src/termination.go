@@ -0,0 +1,153 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RunState is the progress of a run that termination criteria are checked against.
+type RunState struct {
+	Objective       Objective
+	Generation      int
+	BestFitness     float64
+	LastImprovement int // generation in which BestFitness last improved
+	Elapsed         time.Duration
+	Evaluations     int     // fitness evaluations made in this run
+	Diversity       float64 // share of distinct individuals, between 0 and 1, see MinDiversity
+}
+
+// TerminationCriterion decides when a run should stop. Check returns a human readable
+// reason and true when the criterion is satisfied.
+type TerminationCriterion interface {
+	Check(state RunState) (string, bool)
+}
+
+type maxGenerations int
+
+// MaxGenerations stops the run once the population has been bred for n generations.
+func MaxGenerations(n int) TerminationCriterion {
+	return maxGenerations(n)
+}
+
+func (m maxGenerations) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("max generations (%d) reached", int(m)), state.Generation >= int(m)
+}
+
+type targetFitness float64
+
+// TargetFitness stops the run once the best fitness reaches target, from above when
+// minimizing.
+func TargetFitness(target float64) TerminationCriterion {
+	return targetFitness(target)
+}
+
+func (t targetFitness) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("target fitness (%v) reached", float64(t)), !state.Objective.better(float64(t), state.BestFitness)
+}
+
+type stagnation int
+
+// Stagnation stops the run when the best fitness has not improved for n generations.
+func Stagnation(n int) TerminationCriterion {
+	return stagnation(n)
+}
+
+func (s stagnation) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("no improvement for %d generations", int(s)), state.Generation-state.LastImprovement >= int(s)
+}
+
+type timeLimit time.Duration
+
+// TimeLimit stops the run once the wall-clock budget d is spent.
+func TimeLimit(d time.Duration) TerminationCriterion {
+	return timeLimit(d)
+}
+
+func (t timeLimit) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("time limit (%v) reached", time.Duration(t)), state.Elapsed >= time.Duration(t)
+}
+
+type maxEvaluations int
+
+// MaxEvaluations stops the run once it has made n fitness evaluations.
+func MaxEvaluations(n int) TerminationCriterion {
+	return maxEvaluations(n)
+}
+
+func (m maxEvaluations) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("evaluation budget (%d) spent", int(m)), state.Evaluations >= int(m)
+}
+
+type minDiversity float64
+
+// MinDiversity stops the run when the share of distinct individuals in the population
+// drops below threshold. Runs whose criteria contain MinDiversity always measure
+// diversity; see DiversityKeyer.
+func MinDiversity(threshold float64) TerminationCriterion {
+	return minDiversity(threshold)
+}
+
+// usesDiversity reports whether criterion contains a MinDiversity criterion.
+func usesDiversity(criterion TerminationCriterion) bool {
+	switch c := criterion.(type) {
+	case minDiversity:
+		return true
+	case anyOf:
+		for _, criterion := range c {
+			if usesDiversity(criterion) {
+				return true
+			}
+		}
+	case allOf:
+		for _, criterion := range c {
+			if usesDiversity(criterion) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m minDiversity) Check(state RunState) (string, bool) {
+	return fmt.Sprintf("diversity below %v", float64(m)), state.Diversity < float64(m)
+}
+
+type anyOf []TerminationCriterion
+
+// Or is satisfied as soon as one of the criteria is. The reason of the first satisfied
+// criterion is reported.
+func Or(criteria ...TerminationCriterion) TerminationCriterion {
+	return anyOf(criteria)
+}
+
+func (a anyOf) Check(state RunState) (string, bool) {
+	for _, criterion := range a {
+		if reason, ok := criterion.Check(state); ok {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+type allOf []TerminationCriterion
+
+// And is satisfied only when all of the criteria are. The reasons are joined together.
+func And(criteria ...TerminationCriterion) TerminationCriterion {
+	return allOf(criteria)
+}
+
+func (a allOf) Check(state RunState) (string, bool) {
+	if len(a) == 0 {
+		return "", false
+	}
+	reasons := make([]string, 0, len(a))
+	for _, criterion := range a {
+		reason, ok := criterion.Check(state)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, reason)
+	}
+	return strings.Join(reasons, " and "), true
+}